@@ -0,0 +1,137 @@
+package board
+
+// bitStride es el ancho de fila en BitBoard: BoardSize columnas más una columna
+// siempre vacía que corta las líneas horizontales y diagonales entre filas
+const bitStride = BoardSize + 1
+
+// bitWords son las palabras de 64 bits necesarias para BoardSize*bitStride bits
+const bitWords = (BoardSize*bitStride + 63) / 64
+
+// bitset es un conjunto de casillas; la casilla (fila, col) es el bit fila*bitStride + col
+type bitset [bitWords]uint64
+
+// BitBoard es una representación del tablero con una máscara por jugador
+// Pensada para comprobaciones rápidas de victoria (CheckWinBit)
+type BitBoard struct {
+	black, white bitset
+}
+
+// ToBitBoard convierte un Board en BitBoard
+func ToBitBoard(b Board) BitBoard {
+	var bb BitBoard
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			switch b[r][c] {
+			case 'B':
+				bb.black.set(r*bitStride + c)
+			case 'W':
+				bb.white.set(r*bitStride + c)
+			}
+		}
+	}
+	return bb
+}
+
+// Board convierte el BitBoard de vuelta a Board
+func (bb BitBoard) Board() Board {
+	var b Board
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			i := r*bitStride + c
+			if bb.black.has(i) {
+				b[r][c] = 'B'
+			} else if bb.white.has(i) {
+				b[r][c] = 'W'
+			}
+		}
+	}
+	return b
+}
+
+// CheckWinBit verifica si 'player' tiene WinLength piedras en línea
+// Equivale a CheckWin(bb.Board(), player); ver HasLine
+func (bb BitBoard) CheckWinBit(player rune) bool {
+	return bb.HasLine(player, WinLength)
+}
+
+// HasLine verifica si 'player' tiene 'length' o más piedras en línea
+// Para cada dirección (desplazamiento 1, bitStride, bitStride+1 y bitStride-1)
+// hace AND de la máscara con versiones desplazadas de sí misma: el bit i queda
+// activo solo si hay 'length' piedras seguidas desde i
+// Parámetros:
+// - player: 'B' o 'W'
+// - length: Piedras en línea buscadas (1 o más)
+// Retorna: true si existe alguna línea de al menos 'length' piedras
+func (bb BitBoard) HasLine(player rune, length int) bool {
+	stones := bb.black
+	if player == 'W' {
+		stones = bb.white
+	}
+	for _, shift := range [...]uint{1, bitStride, bitStride + 1, bitStride - 1} {
+		if !runsFrom(stones, shift, length).empty() {
+			return true
+		}
+	}
+	return false
+}
+
+// runsFrom retorna los bits i de 'stones' desde los que hay 'length' piedras
+// seguidas en la dirección 'shift'. Duplica la longitud (1, 2, 4...) mientras
+// no pase de 'length' y cierra con un último desplazamiento que solapa dos
+// tramos: para 6 son pares, cuartetos y cuarteto + par desplazado 2
+func runsFrom(stones bitset, shift uint, length int) bitset {
+	runs, n := stones, 1
+	for 2*n <= length && !runs.empty() {
+		runs.and(runs.shiftRight(uint(n) * shift))
+		n *= 2
+	}
+	if n < length {
+		runs.and(runs.shiftRight(uint(length-n) * shift))
+	}
+	return runs
+}
+
+// set activa el bit 'i'
+func (s *bitset) set(i int) {
+	s[i/64] |= 1 << uint(i%64)
+}
+
+// has indica si el bit 'i' está activo
+func (s *bitset) has(i int) bool {
+	return s[i/64]&(1<<uint(i%64)) != 0
+}
+
+// and deja en 's' la intersección con 'o'
+func (s *bitset) and(o bitset) {
+	for i := range s {
+		s[i] &= o[i]
+	}
+}
+
+// empty indica si no hay ningún bit activo
+func (s bitset) empty() bool {
+	for _, w := range s {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// shiftRight desplaza el conjunto 'n' bits hacia índices menores:
+// el bit i+n pasa al bit i
+func (s bitset) shiftRight(n uint) bitset {
+	var out bitset
+	words, bits := int(n/64), n%64
+	for i := range out {
+		src := i + words
+		if src >= bitWords {
+			break
+		}
+		out[i] = s[src] >> bits
+		if bits != 0 && src+1 < bitWords {
+			out[i] |= s[src+1] << (64 - bits)
+		}
+	}
+	return out
+}
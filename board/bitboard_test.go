@@ -0,0 +1,71 @@
+package board
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// randomBoard llena cada casilla con probabilidad 'density', negras o blancas al azar
+func randomBoard(rng *rand.Rand, density float64) Board {
+	var b Board
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			if rng.Float64() < density {
+				b[r][c] = 'B'
+				if rng.Intn(2) == 0 {
+					b[r][c] = 'W'
+				}
+			}
+		}
+	}
+	return b
+}
+
+// TestCheckWinBitMatchesCheckWin compara la versión de bits con CheckWin en
+// posiciones al azar de distinta densidad, y HasLine con Rules.CheckWin para
+// otras longitudes de victoria
+func TestCheckWinBitMatchesCheckWin(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	wins := 0
+	for i := 0; i < 2000; i++ {
+		b := randomBoard(rng, 0.2+0.6*rng.Float64())
+		bits := ToBitBoard(b)
+		if bits.Board() != b {
+			t.Fatalf("posición %d: Board() no recupera el tablero original", i)
+		}
+		for _, player := range []rune{'B', 'W'} {
+			want := CheckWin(b, player)
+			if got := bits.CheckWinBit(player); got != want {
+				t.Fatalf("posición %d, %q: CheckWinBit = %v, CheckWin = %v\n%v", i, player, got, want, b)
+			}
+			if want {
+				wins++
+			}
+			length := 1 + i%9
+			rules := Rules{WinLength: length}
+			if got, want := bits.HasLine(player, length), rules.CheckWin(b, player); got != want {
+				t.Fatalf("posición %d, %q, longitud %d: HasLine = %v, CheckWin = %v", i, player, length, got, want)
+			}
+		}
+	}
+	// Las posiciones densas deben cubrir ambos resultados
+	if wins == 0 || wins == 4000 {
+		t.Fatalf("%d victorias en 4000 comprobaciones: las posiciones no prueban ambos casos", wins)
+	}
+}
+
+// BenchmarkCheckWin y BenchmarkCheckWinBit comparan ambas comprobaciones sobre
+// la misma posición sin ganador (el peor caso: no hay salida temprana)
+func BenchmarkCheckWin(b *testing.B) {
+	pos := randomBoard(rand.New(rand.NewSource(1)), 0.3)
+	for i := 0; i < b.N; i++ {
+		CheckWin(pos, 'B')
+	}
+}
+
+func BenchmarkCheckWinBit(b *testing.B) {
+	bits := ToBitBoard(randomBoard(rand.New(rand.NewSource(1)), 0.3))
+	for i := 0; i < b.N; i++ {
+		bits.CheckWinBit('B')
+	}
+}
@@ -1,9 +1,16 @@
 package board
 
 import (
+	"errors"
+	"fmt"
+	"sort"
 	"strings"
 )
 
+// BoardSize es fijo: Board es un arreglo que se copia, se compara con == y
+// sirve de clave de mapas, y todo el motor depende de eso, así que no hay
+// variantes de otro tamaño (13x13, 15x15). La longitud de victoria sí se
+// configura con Rules.WinLength; WinLength es la de Connect6
 const (
 	BoardSize = 19
 	WinLength = 6
@@ -16,29 +23,161 @@ type Position struct{ Row, Col int }
 
 // Move representa un movimiento con dos posiciones
 // [0]: Primera posición
-// [1]: Segunda posición
+// [1]: Segunda posición (NoPosition si el movimiento es de una sola piedra)
 type Move [2]Position
 
+// NoPosition es el centinela (-1,-1) usado en move[1] cuando el movimiento
+// coloca una sola piedra (la apertura de Connect6)
+var NoPosition = Position{-1, -1}
+
+// ResignMove es el centinela de rendición: ninguna de sus posiciones está en el tablero
+// El motor MCTS nunca lo produce porque sus jugadas siempre tienen move[0] válido
+var ResignMove = Move{NoPosition, NoPosition}
+
 // Board representa el tablero del juego
 // Usa '\x00' para celdas vacías, 'B' para negras, 'W' para blancas
 type Board [BoardSize][BoardSize]rune
 
+// Get lee una celda del tablero con control de rango
+// Parámetros:
+// - b: Tablero
+// - p: Posición a leer
+// Retorna: Contenido de la celda ('\x00', 'B' o 'W') y false si 'p' está fuera del tablero
+func Get(b Board, p Position) (rune, bool) {
+	if !inBounds(p) {
+		return '\x00', false
+	}
+	return b[p.Row][p.Col], true
+}
+
+// Set escribe una celda del tablero con control de rango
+// Parámetros:
+// - b: Puntero al tablero
+// - p: Posición a escribir
+// - v: Nuevo contenido ('\x00', 'B' o 'W')
+// Retorna: false (sin modificar nada) si 'p' está fuera del tablero
+func Set(b *Board, p Position, v rune) bool {
+	if !inBounds(p) {
+		return false
+	}
+	b[p.Row][p.Col] = v
+	return true
+}
+
+// inBounds indica si 'p' está dentro del tablero
+func inBounds(p Position) bool {
+	return p.Row >= 0 && p.Row < BoardSize && p.Col >= 0 && p.Col < BoardSize
+}
+
 // ApplyMove coloca dos piedras en el tablero
 // Parámetros:
 // - b: Puntero al tablero
 // - move: Movimiento a realizar
 // - player: Jugador actual ('B' o 'W')
-func ApplyMove(b *Board, move Move, player rune) {
-	b[move[0].Row][move[0].Col] = player
-	b[move[1].Row][move[1].Col] = player
+// Retorna: false si alguna posición está fuera del tablero; en ese caso no se
+// coloca ninguna piedra. No comprueba que las celdas estén vacías (ver IsValidMove)
+func ApplyMove(b *Board, move Move, player rune) bool {
+	if !inBounds(move[0]) || (move[1] != NoPosition && !inBounds(move[1])) {
+		return false
+	}
+	Set(b, move[0], player)
+	if move[1] != NoPosition {
+		Set(b, move[1], player)
+	}
+	return true
 }
 
-// CheckWin verifica si un jugador ha ganado
+// Motivos por los que ApplyMoveChecked rechaza un movimiento; los textos son
+// para registros y la interfaz los traduce (ui.MoveErrorText)
+var (
+	ErrOpeningStones = errors.New("la apertura es de una sola piedra")
+	ErrTwoStones     = errors.New("el movimiento debe colocar dos piedras")
+	ErrSamePosition  = errors.New("las dos posiciones son la misma")
+	ErrOutOfBounds   = errors.New("posición fuera del tablero")
+	ErrOccupied      = errors.New("casilla ocupada")
+	ErrIllegalMove   = errors.New("movimiento ilegal")
+)
+
+// MoveError es el error de ApplyMoveChecked: el motivo (uno de los Err* de
+// arriba, accesible con errors.Is) y la posición a la que se refiere, si hay una
+type MoveError struct {
+	Err error
+	Pos Position // NoPosition si el motivo no depende de una casilla
+}
+
+func (e *MoveError) Error() string {
+	switch e.Err {
+	case ErrOutOfBounds:
+		return fmt.Sprintf("la posición (%d,%d) está fuera del tablero", e.Pos.Row, e.Pos.Col)
+	case ErrOccupied:
+		return fmt.Sprintf("la casilla (%d,%d) ya está ocupada", e.Pos.Row, e.Pos.Col)
+	}
+	return e.Err.Error()
+}
+
+func (e *MoveError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyMoveChecked coloca las piedras de 'move' solo si es legal (IsValidMove)
+// Parámetros:
+// - b: Puntero al tablero
+// - move: Movimiento a realizar (move[1] = NoPosition solo en la apertura)
+// - player: Jugador actual ('B' o 'W')
+// Retorna: *MoveError con el motivo si el movimiento es ilegal; el tablero no cambia
+// Para bucles internos que ya generan jugadas legales se sigue usando ApplyMove
+func ApplyMoveChecked(b *Board, move Move, player rune) error {
+	if IsValidMove(*b, move[0], move[1]) {
+		ApplyMove(b, move, player)
+		return nil
+	}
+
+	stones := MoveStoneCount(*b)
+	switch {
+	case stones == 1 && move[1] != NoPosition:
+		return &MoveError{Err: ErrOpeningStones, Pos: NoPosition}
+	case stones == 2 && move[1] == NoPosition:
+		return &MoveError{Err: ErrTwoStones, Pos: NoPosition}
+	case move[0] == move[1]:
+		return &MoveError{Err: ErrSamePosition, Pos: move[0]}
+	}
+	for _, p := range move {
+		if p == NoPosition {
+			continue
+		}
+		if !inBounds(p) {
+			return &MoveError{Err: ErrOutOfBounds, Pos: p}
+		}
+		if b[p.Row][p.Col] != '\x00' {
+			return &MoveError{Err: ErrOccupied, Pos: p}
+		}
+	}
+	return &MoveError{Err: ErrIllegalMove, Pos: NoPosition}
+}
+
+// UnapplyMove deshace un movimiento aplicado previamente con ApplyMove
+// Parámetros:
+// - b: Puntero al tablero
+// - move: Movimiento a deshacer
+// Vuelve a dejar vacías ('\x00') las una o dos celdas del movimiento
+func UnapplyMove(b *Board, move Move) {
+	b[move[0].Row][move[0].Col] = '\x00'
+	if move[1] != NoPosition {
+		b[move[1].Row][move[1].Col] = '\x00'
+	}
+}
+
+// CheckWin verifica si un jugador ha ganado con las reglas estándar
 // Parámetros:
 // - b: Tablero actual
 // - player: Jugador a verificar
 // Retorna: true si el jugador tiene 6 en línea
 func CheckWin(b Board, player rune) bool {
+	return DefaultRules.CheckWin(b, player)
+}
+
+// CheckWin verifica si un jugador tiene r.WinLength piedras en línea
+func (rules Rules) CheckWin(b Board, player rune) bool {
 	directions := []struct{ dr, dc int }{
 		{0, 1},  // Horizontal
 		{1, 0},  // Vertical
@@ -53,8 +192,13 @@ func CheckWin(b Board, player rune) bool {
 			}
 
 			for _, dir := range directions {
+				// Con ExactWinLength la cadena se mide completa, desde su inicio
+				if pr, pc := r-dir.dr, c-dir.dc; rules.ExactWinLength &&
+					pr >= 0 && pr < BoardSize && pc >= 0 && pc < BoardSize && b[pr][pc] == player {
+					continue
+				}
 				count := 1
-				for step := 1; step < WinLength; step++ {
+				for step := 1; step < rules.runLimit(); step++ {
 					nr, nc := r+dir.dr*step, c+dir.dc*step
 					if nr < 0 || nr >= BoardSize || nc < 0 || nc >= BoardSize {
 						break
@@ -64,7 +208,7 @@ func CheckWin(b Board, player rune) bool {
 					}
 					count++
 				}
-				if count >= WinLength {
+				if rules.completes(count) {
 					return true
 				}
 			}
@@ -73,26 +217,151 @@ func CheckWin(b Board, player rune) bool {
 	return false
 }
 
+// CheckWinAt verifica si la piedra en 'p' forma parte de una línea ganadora
+// Solo examina las 4 direcciones que pasan por 'p' (mucho más barato que CheckWin)
+// Parámetros:
+// - b: Tablero actual
+// - p: Posición recién jugada
+// - player: Jugador a verificar
+// Retorna: true si 'p' es de 'player' y hay 6 en línea a través de ella
+func CheckWinAt(b Board, p Position, player rune) bool {
+	return DefaultRules.CheckWinAt(b, p, player)
+}
+
+// CheckWinAt verifica una línea de rules.WinLength a través de 'p'
+func (rules Rules) CheckWinAt(b Board, p Position, player rune) bool {
+	if p.Row < 0 || p.Row >= BoardSize || p.Col < 0 || p.Col >= BoardSize || b[p.Row][p.Col] != player {
+		return false
+	}
+
+	directions := []struct{ dr, dc int }{
+		{0, 1}, {1, 0}, {1, 1}, {1, -1},
+	}
+	for _, dir := range directions {
+		count := 1
+		// Hacia adelante y hacia atrás, hasta WinLength-1 pasos cada una
+		// (la cadena completa con ExactWinLength)
+		for _, sign := range []int{1, -1} {
+			for step := 1; step < rules.runLimit(); step++ {
+				nr, nc := p.Row+sign*dir.dr*step, p.Col+sign*dir.dc*step
+				if nr < 0 || nr >= BoardSize || nc < 0 || nc >= BoardSize || b[nr][nc] != player {
+					break
+				}
+				count++
+			}
+		}
+		if rules.completes(count) {
+			return true
+		}
+	}
+	return false
+}
+
+// MoveWins verifica si alguna de las piedras de 'move' completa una línea ganadora
+func MoveWins(b Board, move Move, player rune) bool {
+	return DefaultRules.MoveWins(b, move, player)
+}
+
+// MoveWins verifica con estas reglas si alguna piedra de 'move' completa una línea ganadora
+func (rules Rules) MoveWins(b Board, move Move, player rune) bool {
+	if rules.CheckWinAt(b, move[0], player) {
+		return true
+	}
+	return move[1] != NoPosition && rules.CheckWinAt(b, move[1], player)
+}
+
+// WinningLine obtiene las posiciones de la línea ganadora de un jugador
+// Parámetros:
+// - b: Tablero actual
+// - player: Jugador a verificar
+// Retorna: Las posiciones consecutivas (6 o más si es sobrelínea), o nil si no ha ganado
+func WinningLine(b Board, player rune) []Position {
+	return DefaultRules.WinningLine(b, player)
+}
+
+// WinningLine retorna la primera cadena de 'player' que gana con estas reglas
+// (con ExactWinLength una sobrelínea no cuenta)
+func (rules Rules) WinningLine(b Board, player rune) []Position {
+	directions := []struct{ dr, dc int }{
+		{0, 1},  // Horizontal
+		{1, 0},  // Vertical
+		{1, 1},  // Diagonal derecha
+		{1, -1}, // Diagonal izquierda
+	}
+
+	inRange := func(r, c int) bool {
+		return r >= 0 && r < BoardSize && c >= 0 && c < BoardSize
+	}
+
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			if b[r][c] != player {
+				continue
+			}
+
+			for _, dir := range directions {
+				// Solo desde el inicio de la cadena, para devolverla completa
+				if pr, pc := r-dir.dr, c-dir.dc; inRange(pr, pc) && b[pr][pc] == player {
+					continue
+				}
+				var line []Position
+				for nr, nc := r, c; inRange(nr, nc) && b[nr][nc] == player; nr, nc = nr+dir.dr, nc+dir.dc {
+					line = append(line, Position{nr, nc})
+				}
+				if rules.completes(len(line)) {
+					return line
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // IsValidMove valida si un movimiento es legal
 // Parámetros:
 // - b: Tablero actual
 // - p1: Primera posición del movimiento
 // - p2: Segunda posición del movimiento
-// Retorna: true si ambas posiciones están vacías y dentro del tablero
+// Retorna: true si ambas posiciones están vacías y dentro del tablero.
+// En la apertura (MoveStoneCount == 1) p2 debe ser NoPosition.
 func IsValidMove(b Board, p1, p2 Position) bool {
 	if p1 == p2 {
 		return false
 	}
 
-	inRange := func(p Position) bool {
-		return p.Row >= 0 && p.Row < BoardSize && p.Col >= 0 && p.Col < BoardSize
+	if MoveStoneCount(b) == 1 {
+		return p2 == NoPosition && inBounds(p1) && b[p1.Row][p1.Col] == '\x00'
 	}
 
-	return inRange(p1) && inRange(p2) &&
+	return inBounds(p1) && inBounds(p2) &&
 		b[p1.Row][p1.Col] == '\x00' &&
 		b[p2.Row][p2.Col] == '\x00'
 }
 
+// MoveStoneCount indica cuántas piedras debe colocar el próximo movimiento
+// Parámetros:
+// - b: Tablero actual
+// Retorna: 1 si el tablero está vacío (apertura de Connect6), 2 en otro caso
+func MoveStoneCount(b Board) int {
+	if IsBoardEmpty(b) {
+		return 1
+	}
+	return 2
+}
+
+// StoneCount cuenta las piedras colocadas en el tablero (de ambos jugadores)
+func StoneCount(b Board) int {
+	count := 0
+	for _, row := range b {
+		for _, cell := range row {
+			if cell != '\x00' {
+				count++
+			}
+		}
+	}
+	return count
+}
+
 // SwitchPlayer alterna entre jugadores
 // Parámetros:
 // - player: Jugador actual
@@ -117,190 +386,149 @@ func CloneBoard(b Board) Board {
 }
 
 // GetCurrentPlayer determina quién debe jugar
+// En Connect6 las negras abren con una piedra y después cada turno coloca dos:
+// tras las (total-1) piedras posteriores a la apertura se han jugado
+// (total-1)/2 turnos completos, y las blancas mueven cuando ese número es par
 // Parámetros:
 // - b: Tablero actual
-// Retorna: 'B' si igual número de piedras, 'W' si hay más negras
+// Retorna: 'B' con el tablero vacío o si le toca a las negras, 'W' en otro caso
+// (con un número par de piedras el turno de dos está a medias y sigue el mismo jugador)
 func GetCurrentPlayer(b Board) rune {
-	var black, white int
-	for _, row := range b {
-		for _, cell := range row {
-			switch cell {
-			case 'B':
-				black++
-			case 'W':
-				white++
-			}
-		}
-	}
-	if black == white {
+	total := StoneCount(b)
+	if total == 0 {
 		return 'B'
 	}
-	return 'W'
+	if ((total-1)/2)%2 == 0 {
+		return 'W'
+	}
+	return 'B'
 }
 
 // EvaluateBoard evalúa la ventaja global de 'player' en el tablero 'b'.
 // Retorna un valor positivo si es mejor para 'player', negativo si es mejor para el rival.
 func EvaluateBoard(b Board, player rune) float64 {
+	return DefaultRules.EvaluateBoard(b, player)
+}
+
+// EvaluateBoard evalúa el tablero puntuando las cadenas según rules.WinLength
+func (rules Rules) EvaluateBoard(b Board, player rune) float64 {
 	opponent := SwitchPlayer(player)
 	playerScore := 0
 	oppScore := 0
 
-	// Recorremos el tablero en busca de cadenas de 'B' o 'W'.
-	// Para cada casilla con una ficha, examinamos la longitud de la cadena en
-	// 4 direcciones. Obtenemos la longitud y si está bloqueada a izquierda/derecha.
-	directions := []struct{ dr, dc int }{
-		{0, 1},  // Horizontal
-		{1, 0},  // Vertical
-		{1, 1},  // Diagonal \
-		{1, -1}, // Diagonal /
+	// Recorremos las cadenas máximas de 'B' o 'W' en las 4 direcciones.
+	// Cada cadena se puntúa una sola vez, con sus extremos reales.
+	runs := ChainRuns(b)
+	for _, run := range runs {
+		score := rules.WeightedChainScore(run.Length, run.BlockedStart, run.BlockedEnd)
+		if run.Player == player {
+			playerScore += score
+		} else if run.Player == opponent {
+			oppScore += score
+		}
 	}
 
-	for r := 0; r < BoardSize; r++ {
-		for c := 0; c < BoardSize; c++ {
-			cell := b[r][c]
-			if cell == '\x00' {
-				continue
-			}
-			// Vemos para cada dirección
-			for _, d := range directions {
-				length, blockedA, blockedB := chainInfo(b, r, c, d.dr, d.dc, cell)
-
-				if cell == player {
-					playerScore += WeightedChainScore(length, blockedA, blockedB)
-				} else if cell == opponent {
-					oppScore += WeightedChainScore(length, blockedA, blockedB)
-				}
-			}
-		}
+	// Patrones con un hueco ("X.XX", "XX.XX", ...)
+	playerScore += rules.gapPatternScore(b, runs, player)
+	oppScore += rules.gapPatternScore(b, runs, opponent)
+
+	// Doble cuatro abierto: dos amenazas que no se pueden bloquear a la vez
+	if rules.CountOpenFours(b, player) >= 2 {
+		playerScore += rules.weights().DoubleFour
+	}
+	if rules.CountOpenFours(b, opponent) >= 2 {
+		oppScore += rules.weights().DoubleFour
 	}
 
+	// Casillas donde una sola piedra crea dos amenazas a la vez
+	playerScore += rules.doubleThreatScore(b, player)
+	oppScore += rules.doubleThreatScore(b, opponent)
+
 	// Un valor final. Podríamos normalizarlo, pero por simplicidad
 	// devolvemos la diferencia. Cuanto mayor => más favorable a 'player'.
-	return float64(playerScore - oppScore)
-}
+	eval := float64(playerScore - oppScore)
 
-// WeightedChainScore asigna un valor según la longitud de la cadena y si
-// está bloqueada a uno o ambos extremos.
-func WeightedChainScore(length int, blockedA, blockedB bool) int {
-	// Puntos base si la cadena es >= 6 => victoria instantánea
-	if length >= 6 {
-		return 999999
+	// Control de casillas vacías en líneas abiertas (ver InfluenceScore)
+	if w := rules.weights().Influence; w != 0 {
+		eval += w * (rules.InfluenceScore(b, player) - rules.InfluenceScore(b, opponent))
 	}
+	return eval
+}
 
-	openEnds := 0
-	if !blockedA {
-		openEnds++
-	}
-	if !blockedB {
-		openEnds++
-	}
+// CountOpenFours cuenta las cadenas de 4 con ambos extremos libres (reglas estándar)
+// Parámetros:
+// - b: Tablero actual
+// - player: Jugador a evaluar
+// Retorna: Número de cadenas distintas; 2 o más es un doble cuatro
+func CountOpenFours(b Board, player rune) int {
+	return DefaultRules.CountOpenFours(b, player)
+}
 
-	// Ejemplo de escalado
-	// 5 en línea:
-	if length == 5 {
-		// con 2 extremos abiertos => un movimiento (2 piedras) => gana
-		if openEnds == 2 {
-			return 100000
-		} else if openEnds == 1 {
-			return 50000
+// CountOpenFours cuenta las cadenas abiertas a las que les faltan 2 piedras
+// para rules.WinLength (un "cuatro" en Connect6). Solo cadenas seguidas: los
+// cuatros partidos ("XX.XX") los encuentra FindSplitFours
+func (rules Rules) CountOpenFours(b Board, player rune) int {
+	count := 0
+	for _, run := range ChainRuns(b) {
+		if run.Player == player && run.Length == rules.WinLength-2 && !run.BlockedStart && !run.BlockedEnd {
+			count++
 		}
-		return 20000
 	}
+	return count
+}
 
-	// 4 en línea:
-	// Si openEnds=2 => se puede poner 2 fichas y hacer 6
-	// p.ej. 4 + 2 = 6 => su peligrosidad sube mucho
-	if length == 4 {
-		if openEnds == 2 {
-			return 30000 // sube este valor
-		} else if openEnds == 1 {
-			return 15000
-		}
-		return 5000
-	}
+// WeightedChainScore asigna un valor según la longitud de la cadena y si
+// está bloqueada a uno o ambos extremos (reglas estándar de Connect6).
+func WeightedChainScore(length int, blockedA, blockedB bool) int {
+	return DefaultRules.WeightedChainScore(length, blockedA, blockedB)
+}
 
-	// 3 en línea:
-	if length == 3 {
-		if openEnds == 2 {
-			// 3 + 2 = 5 => no gana de inmediato, pero se queda a 1
-			// Súbelo un poco
-			return 7000
-		} else if openEnds == 1 {
-			return 3000
-		}
-		return 1000
+// WeightedChainScore puntúa una cadena según las piedras que le faltan para
+// llegar a rules.WinLength, de modo que los umbrales escalan con la variante.
+// Los comentarios indican el caso equivalente en Connect6 (WinLength = 6).
+// Los puntajes salen de rules.Weights (ver EvalWeights y WithWeights).
+func (rules Rules) WeightedChainScore(length int, blockedA, blockedB bool) int {
+	if length <= 0 {
+		return 0
 	}
 
-	// 2 en línea:
-	if length == 2 {
-		if openEnds == 2 {
-			return 1500
-		}
-		return 500
-	}
+	w := rules.weights()
 
-	// 1 sola
-	if length == 1 {
-		return 50
+	// Puntos base si la cadena es >= WinLength => victoria instantánea
+	missing := rules.WinLength - length
+	if missing <= 0 {
+		return w.Win
 	}
 
-	return 0
-}
-
-// chainInfo retorna la longitud de la cadena que inicia en (r,c) en la dirección (dr, dc),
-// y si está bloqueada en los extremos.
-func chainInfo(b Board, r, c, dr, dc int, player rune) (length int, blockedA, blockedB bool) {
-	// Empezamos con length=1 (la propia casilla (r,c))
-	length = 1
-
-	// Avanzamos "hacia adelante" en la dirección (dr,dc)
-	step := 1
-	for {
-		nr := r + dr*step
-		nc := c + dc*step
-		if nr < 0 || nr >= BoardSize || nc < 0 || nc >= BoardSize {
-			// salimos del tablero => se considera "bloqueado"
-			blockedB = true
-			break
-		}
-		if b[nr][nc] == player {
-			length++
-			step++
-			continue
-		}
-		if b[nr][nc] == '\x00' {
-			// hueco => no bloqueado, paramos la cadena
-			blockedB = false
-		} else {
-			// hay una ficha rival => bloqueado
-			blockedB = true
-		}
-		break
+	openEnds := 0
+	if !blockedA {
+		openEnds++
+	}
+	if !blockedB {
+		openEnds++
 	}
 
-	// Avanzamos "hacia atrás" en la dirección opuesta (-dr, -dc)
-	step = 1
-	for {
-		nr := r - dr*step
-		nc := c - dc*step
-		if nr < 0 || nr >= BoardSize || nc < 0 || nc >= BoardSize {
-			blockedA = true
-			break
-		}
-		if b[nr][nc] == player {
-			length++
-			step++
-			continue
-		}
-		if b[nr][nc] == '\x00' {
-			blockedA = false
-		} else {
-			blockedA = true
+	// Cada caso elige entre [cerrada, medio abierta, abierta] según openEnds
+	switch missing {
+	case 1:
+		// 5 en línea: con 2 extremos abiertos => un movimiento (2 piedras) => gana
+		return [3]int{w.FiveClosed, w.FiveHalf, w.FiveOpen}[openEnds]
+	case 2:
+		// 4 en línea: con openEnds=2 se pueden poner 2 fichas y hacer 6
+		return [3]int{w.FourClosed, w.FourHalf, w.FourOpen}[openEnds]
+	case 3:
+		// 3 en línea: 3 + 2 = 5 => no gana de inmediato, pero se queda a 1
+		return [3]int{w.ThreeClosed, w.ThreeHalf, w.ThreeOpen}[openEnds]
+	case 4:
+		// 2 en línea
+		if openEnds == 2 {
+			return w.TwoOpen
 		}
-		break
+		return w.TwoBlocked
 	}
 
-	return length, blockedA, blockedB
+	// 1 sola (o cadenas aún más lejos de la victoria)
+	return w.Single
 }
 
 // EvaluatePosition calcula valor estratégico de una posición
@@ -311,6 +539,11 @@ func chainInfo(b Board, r, c, dr, dc int, player rune) (length int, blockedA, bl
 // - player: Jugador a evaluar
 // Retorna: Puntaje numérico (mayor = mejor posición)
 func EvaluatePosition(b Board, r, c int, player rune) int {
+	return positionScore(&b, r, c, player)
+}
+
+// positionScore es EvaluatePosition sin copiar el tablero
+func positionScore(b *Board, r, c int, player rune) int {
 	score := 0
 	directions := []struct{ dr, dc int }{
 		{0, 1}, {1, 0}, {1, 1}, {1, -1},
@@ -342,15 +575,33 @@ func EvaluatePosition(b Board, r, c int, player rune) int {
 }
 
 // baseSmartMoves genera movimientos "básicos" sin filtrar demasiado
-// Retorna: Lista de hasta 100 pares de posiciones prioritarias
-func baseSmartMoves(b Board) []Move {
-	positions := GetPriorityPositions(b, 2)
-	var moves []Move
-	maxPairs := 100
+// Respeta MoveStoneCount: en la apertura cada posición prioritaria es un
+// movimiento de una piedra (con NoPosition como segunda)
+// Los pares se forman por distancia creciente en la lista de posiciones
+// (i, i+1), luego (i, i+2)..., para que el tope reparta los pares por todo el
+// tablero en lugar de agotarlo con las primeras posiciones
+// Retorna: Lista de hasta 'maxPairs' movimientos de dos piedras
+func (rules Rules) baseSmartMoves(b Board, empties *EmptySet, maxPairs int) []Move {
+	positions := rules.priorityPositions(b, empties)
 
-	for i := 0; i < len(positions); i++ {
-		for j := i + 1; j < len(positions); j++ {
-			moves = append(moves, Move{positions[i], positions[j]})
+	if MoveStoneCount(b) == 1 {
+		moves := make([]Move, 0, len(positions))
+		for _, p := range positions {
+			moves = append(moves, Move{p, NoPosition})
+		}
+		return moves
+	}
+
+	// Se reserva de una vez lo que se va a generar (n*(n-1)/2 pares, hasta maxPairs)
+	size := len(positions) * (len(positions) - 1) / 2
+	if size > maxPairs {
+		size = maxPairs
+	}
+	moves := make([]Move, 0, size)
+
+	for gap := 1; gap < len(positions); gap++ {
+		for i := 0; i+gap < len(positions); i++ {
+			moves = append(moves, Move{positions[i], positions[i+gap]})
 			if len(moves) >= maxPairs {
 				return moves
 			}
@@ -363,49 +614,230 @@ func baseSmartMoves(b Board) []Move {
 // En esta versión, añadimos la idea de priorizar ciertas jugadas.
 // Ejemplo: si hubiera una jugada ganadora para B o W (aunque sin saber quién juega),
 // se agregan primero. Luego se añaden las jugadas base.
+// En las primeras jugadas (hasta symmetryMaxStones piedras) los movimientos
+// equivalentes por simetría del tablero se descartan (CanonicalMoves).
 func GenerateSmartMoves(b Board) []Move {
+	return DefaultRules.GenerateSmartMoves(b)
+}
+
+// GenerateSmartMoves genera movimientos con rules.WinLength y los topes
+// rules.MaxPairs (pares de baseSmartMoves) y rules.MaxMoves (total)
+func (rules Rules) GenerateSmartMoves(b Board) []Move {
+	return rules.GenerateSmartMovesIn(b, nil)
+}
+
+// GenerateSmartMovesIn es GenerateSmartMoves consultando 'empties' (las casillas
+// vacías de 'b', al día) para hallar las posiciones candidatas: con el tablero
+// casi lleno recorre solo las vacías. Con empties = nil escanea el tablero; el
+// resultado es el mismo en ambos casos
+func (rules Rules) GenerateSmartMovesIn(b Board, empties *EmptySet) []Move {
 	var moves []Move
+	maxPairs, maxMoves := rules.moveCaps()
+
+	// Apertura: solo movimientos de una piedra (ver baseSmartMoves)
+	if MoveStoneCount(b) == 1 {
+		return canonicalOpening(b, rules.baseSmartMoves(b, empties, maxPairs))
+	}
 
 	// 1) Jugada ganadora para negras
-	if winB := FindWinningMove(b, 'B'); winB != nil {
+	if winB := rules.FindWinningMove(b, 'B'); winB != nil {
 		moves = append(moves, *winB)
 	}
 	// 2) Jugada ganadora para blancas
-	if winW := FindWinningMove(b, 'W'); winW != nil {
+	if winW := rules.FindWinningMove(b, 'W'); winW != nil {
 		moves = append(moves, *winW)
 	}
 
-	// 3) baseSmartMoves
-	base := baseSmartMoves(b)
-	moves = append(moves, base...)
+	// 3) baseSmartMoves (sin victorias delante se usa su slice tal cual)
+	base := rules.baseSmartMoves(b, empties, maxPairs)
+	if moves == nil {
+		moves = base
+	} else {
+		moves = append(moves, base...)
+	}
+	moves = canonicalOpening(b, moves)
 
 	// Opcional: recortar
-	if len(moves) > 150 {
-		moves = moves[:150]
+	if len(moves) > maxMoves {
+		moves = moves[:maxMoves]
 	}
 	return moves
 }
 
+// symmetryMaxStones es el máximo de piedras con que GenerateSmartMoves busca
+// simetrías: después es raro que la posición sea simétrica, y comprobarlo en
+// cada nodo y cada paso de simulación cuesta más de lo que ahorra
+const symmetryMaxStones = 7
+
+// canonicalOpening aplica CanonicalMoves solo en las primeras jugadas
+func canonicalOpening(b Board, moves []Move) []Move {
+	if StoneCount(b) > symmetryMaxStones {
+		return moves
+	}
+	return CanonicalMoves(b, moves)
+}
+
 // FindWinningMove busca victoria inmediata
 // Parámetros:
 // - b: Tablero actual
 // - player: Jugador a verificar
 // Retorna: Movimiento ganador si existe, nil en caso contrario
 func FindWinningMove(b Board, player rune) *Move {
-	moves := baseSmartMoves(b)
-	for _, move := range moves {
-		testBoard := CloneBoard(b)
-		ApplyMove(&testBoard, move, player)
-		if CheckWin(testBoard, player) {
-			return &move
+	return DefaultRules.FindWinningMove(b, player)
+}
+
+// FindWinningMove busca victoria inmediata con rules.WinLength
+// Recorre todas las ventanas de WinLength celdas en las 4 direcciones: una
+// ventana sin piedras rivales y con a lo sumo 2 huecos se completa en un turno
+func (rules Rules) FindWinningMove(b Board, player rune) *Move {
+	var win *Move
+	rules.winningWindows(b, player, func(move Move) bool {
+		win = &move
+		return false
+	})
+	return win
+}
+
+// FindAllWinningMoves lista todas las victorias inmediatas distintas de 'player'
+// Parámetros:
+// - b: Tablero actual
+// - player: Jugador a verificar
+// Retorna: Un movimiento por cada conjunto distinto de huecos que completa una
+// línea (en orden de recorrido del tablero); nil si no hay ninguno
+func FindAllWinningMoves(b Board, player rune) []Move {
+	return DefaultRules.FindAllWinningMoves(b, player)
+}
+
+// FindAllWinningMoves lista las victorias inmediatas con rules.WinLength
+// Cuando basta una piedra, la segunda va en la misma celda libre que usa
+// FindWinningMove, así que dos ventanas con el mismo hueco dan un solo movimiento
+func (rules Rules) FindAllWinningMoves(b Board, player rune) []Move {
+	var wins []Move
+	seen := make(map[Move]bool)
+	rules.winningWindows(b, player, func(move Move) bool {
+		if key := normalizePair(move); !seen[key] {
+			seen[key] = true
+			wins = append(wins, move)
 		}
+		return true
+	})
+	return wins
+}
+
+// winningWindows llama a 'visit' con el movimiento que completa cada ventana
+// ganable de 'player' (ver FindWinningMove) hasta que 'visit' retorne false
+func (rules Rules) winningWindows(b Board, player rune, visit func(Move) bool) {
+	if MoveStoneCount(b) == 1 {
+		return
 	}
-	return nil
+
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			for _, d := range chainDirections {
+				endR, endC := r+d.dr*(rules.WinLength-1), c+d.dc*(rules.WinLength-1)
+				if endR < 0 || endR >= BoardSize || endC < 0 || endC >= BoardSize {
+					continue
+				}
+
+				// Huecos de la ventana en un arreglo fijo: con más de 2 no se
+				// completa en un turno, y así cada ventana no asigna memoria
+				var empties [2]Position
+				gaps := 0
+				blocked := false
+				for step := 0; step < rules.WinLength && !blocked; step++ {
+					nr, nc := r+d.dr*step, c+d.dc*step
+					switch b[nr][nc] {
+					case player:
+					case '\x00':
+						if gaps < len(empties) {
+							empties[gaps] = Position{nr, nc}
+						}
+						gaps++
+						blocked = gaps > len(empties)
+					default:
+						blocked = true
+					}
+				}
+				if blocked || gaps == 0 {
+					continue
+				}
+				// Con ExactWinLength la ventana no puede prolongar una cadena propia
+				if rules.ExactWinLength && (isPlayerAt(b, r-d.dr, c-d.dc, player) || isPlayerAt(b, endR+d.dr, endC+d.dc, player)) {
+					continue
+				}
+
+				move := Move{empties[0], NoPosition}
+				if gaps == 2 {
+					move[1] = empties[1]
+				} else if second, ok := rules.secondStone(&b, empties[0], player); ok {
+					// Basta una piedra: la segunda la elige secondStone
+					move[1] = second
+				} else {
+					continue
+				}
+				if !visit(move) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// isPlayerAt indica si (r,c) está dentro del tablero y tiene una piedra de 'player'
+func isPlayerAt(b Board, r, c int, player rune) bool {
+	return inBounds(Position{r, c}) && b[r][c] == player
+}
+
+// secondStone elige dónde va la segunda piedra de un movimiento que gana con
+// una sola piedra en 'win': la casilla vacía de mejor EvaluatePosition para
+// 'player', fuera de las prolongaciones de las líneas que completa 'win' (con
+// ExactWinLength una piedra allí alargaría la línea y dejaría de ganar)
+// Retorna: false si no queda ninguna casilla así
+func (rules Rules) secondStone(b *Board, win Position, player rune) (Position, bool) {
+	var skip [BoardSize][BoardSize]bool
+	skip[win.Row][win.Col] = true
+	for _, d := range chainDirections {
+		ahead := countDirection(b, win.Row, win.Col, d.dr, d.dc, player)
+		behind := countDirection(b, win.Row, win.Col, -d.dr, -d.dc, player)
+		if 1+ahead+behind < rules.WinLength {
+			continue
+		}
+		for _, p := range []Position{
+			{win.Row + d.dr*(ahead+1), win.Col + d.dc*(ahead+1)},
+			{win.Row - d.dr*(behind+1), win.Col - d.dc*(behind+1)},
+		} {
+			if inBounds(p) {
+				skip[p.Row][p.Col] = true
+			}
+		}
+	}
+
+	var best Position
+	bestScore := -1
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			if b[r][c] != '\x00' || skip[r][c] {
+				continue
+			}
+			if score := positionScore(b, r, c, player); score > bestScore {
+				best, bestScore = Position{r, c}, score
+			}
+		}
+	}
+	return best, bestScore >= 0
 }
 
+// FindPairWinningMove busca un movimiento tras el cual 'player' gana con su
+// siguiente movimiento (dos turnos propios seguidos, sin respuesta del rival)
+// Retorna: El primer movimiento del par ganador, o nil si no hay
 func FindPairWinningMove(b Board, player rune) *Move {
+	return DefaultRules.FindPairWinningMove(b, player)
+}
+
+// FindPairWinningMove es FindPairWinningMove con la generación y la victoria de estas reglas
+func (rules Rules) FindPairWinningMove(b Board, player rune) *Move {
 	// Generar todos los movimientos posibles para el primer paso
-	firstMoves := GenerateSmartMoves(b)
+	firstMoves := rules.GenerateSmartMoves(b)
 
 	// Verificar cada par de movimientos consecutivos
 	for _, firstMove := range firstMoves {
@@ -414,7 +846,7 @@ func FindPairWinningMove(b Board, player rune) *Move {
 		ApplyMove(&testBoard, firstMove, player)
 
 		// Generar movimientos para el segundo paso
-		secondMoves := GenerateSmartMoves(testBoard)
+		secondMoves := rules.GenerateSmartMoves(testBoard)
 
 		for _, secondMove := range secondMoves {
 			// Aplicar segundo movimiento
@@ -422,7 +854,7 @@ func FindPairWinningMove(b Board, player rune) *Move {
 			ApplyMove(&finalBoard, secondMove, player)
 
 			// Verificar si se completa la victoria
-			if CheckWin(finalBoard, player) {
+			if rules.CheckWin(finalBoard, player) {
 				return &firstMove // Devolver el primer movimiento del par ganador
 			}
 		}
@@ -430,6 +862,65 @@ func FindPairWinningMove(b Board, player rune) *Move {
 	return nil
 }
 
+// Radios de las posiciones candidatas (ver GetPriorityPositionsAdaptive)
+const (
+	DefaultRadius        = 2  // radio de GenerateSmartMoves
+	maxAdaptiveRadius    = 4  // radio máximo al ampliar
+	adaptiveMinPositions = 50 // por debajo de estas candidatas se amplía el radio
+)
+
+// GetPriorityPositionsAdaptive obtiene las posiciones cercanas a las piedras con
+// un radio que se amplía en posiciones abiertas: empieza en DefaultRadius y
+// crece de uno en uno (hasta 4) mientras haya menos de 50 candidatas, para no
+// perder jugadas de enlace cuando las piedras están dispersas o son pocas
+// Parámetros:
+// - b: Tablero actual
+// Retorna: Posiciones vacías ordenadas por fila y columna
+func GetPriorityPositionsAdaptive(b Board) []Position {
+	return adaptivePriorityPositions(b, nil, DefaultRadius)
+}
+
+// adaptivePriorityPositions es GetPriorityPositionsAdaptive empezando en 'radius'
+// ('empties' como en priorityPositionsIn)
+func adaptivePriorityPositions(b Board, empties *EmptySet, radius int) []Position {
+	positions := priorityPositionsIn(b, empties, radius)
+	for len(positions) < adaptiveMinPositions && radius < maxAdaptiveRadius && !IsBoardEmpty(b) {
+		radius++
+		positions = priorityPositionsIn(b, empties, radius)
+	}
+	return positions
+}
+
+// priorityPositionsIn es GetPriorityPositions usando 'empties' cuando hay menos
+// casillas vacías que piedras: entonces recorre las vacías y se queda con las
+// que tienen alguna piedra a distancia 'radius' o menos. Con empties = nil, o
+// con más vacías que piedras, escanea las piedras como GetPriorityPositions
+func priorityPositionsIn(b Board, empties *EmptySet, radius int) []Position {
+	if empties == nil || 2*empties.Len() > BoardSize*BoardSize {
+		return GetPriorityPositions(b, radius)
+	}
+	var positions []Position
+	for _, p := range empties.Positions() {
+		if hasStoneWithin(&b, p, radius) {
+			positions = append(positions, p)
+		}
+	}
+	sortPositions(positions)
+	return positions
+}
+
+// hasStoneWithin indica si hay alguna piedra a distancia (Chebyshev) 'radius' o menos de 'p'
+func hasStoneWithin(b *Board, p Position, radius int) bool {
+	for r := p.Row - radius; r <= p.Row+radius; r++ {
+		for c := p.Col - radius; c <= p.Col+radius; c++ {
+			if r >= 0 && r < BoardSize && c >= 0 && c < BoardSize && b[r][c] != '\x00' {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetPriorityPositions obtiene ubicaciones clave
 // Añade el área central 5x5 si el tablero está vacío, etc.
 func GetPriorityPositions(b Board, radius int) []Position {
@@ -479,27 +970,102 @@ func IsBoardEmpty(b Board) bool {
 	return true
 }
 
+// IsBoardFull verifica si no quedan celdas vacías en el tablero
+// Retorna: true si las 361 celdas están ocupadas
+func IsBoardFull(b Board) bool {
+	for _, row := range b {
+		for _, cell := range row {
+			if cell == '\x00' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // mapToSlice convierte mapa de posiciones a slice
+// Las posiciones se ordenan por fila y luego por columna para que el orden
+// no dependa del recorrido del mapa (búsquedas reproducibles con la misma semilla)
 func mapToSlice(m map[Position]bool) []Position {
 	result := make([]Position, 0, len(m))
 	for pos := range m {
 		result = append(result, pos)
 	}
+	sortPositions(result)
 	return result
 }
 
+// sortPositions ordena por fila y luego por columna
+func sortPositions(positions []Position) {
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Row != positions[j].Row {
+			return positions[i].Row < positions[j].Row
+		}
+		return positions[i].Col < positions[j].Col
+	})
+}
+
 // GetWinner determina el ganador del juego
 // Retorna: 'B', 'W' o ' ' (sin ganador)
 func GetWinner(board Board) rune {
-	if CheckWin(board, 'B') {
+	return DefaultRules.GetWinner(board)
+}
+
+// GetWinner determina el ganador con estas reglas: 'B', 'W' o ' '
+func (rules Rules) GetWinner(b Board) rune {
+	if rules.CheckWin(b, 'B') {
 		return 'B'
 	}
-	if CheckWin(board, 'W') {
+	if rules.CheckWin(b, 'W') {
 		return 'W'
 	}
 	return ' '
 }
 
+// IsTerminal indica si la partida terminó en 'b'
+// La victoria se comprueba con la representación de bits (ToBitBoard + CheckWinBit)
+// Parámetros:
+// - b: Tablero actual
+// Retorna:
+// - over: true si algún jugador tiene una línea ganadora o el tablero está lleno
+// - winner: 'B' o 'W' si hay ganador (las negras primero, como GetWinner), ' ' en otro caso
+func IsTerminal(b Board) (over bool, winner rune) {
+	return DefaultRules.IsTerminal(b)
+}
+
+// IsTerminal indica si la partida terminó en 'b' con estas reglas
+// Sin ExactWinLength la victoria se comprueba con bits (HasLine de WinLength);
+// con ExactWinLength una sobrelínea no gana y se usa CheckWin
+func (rules Rules) IsTerminal(b Board) (over bool, winner rune) {
+	if rules.ExactWinLength {
+		if winner := rules.GetWinner(b); winner != ' ' {
+			return true, winner
+		}
+		return IsBoardFull(b), ' '
+	}
+	bits := ToBitBoard(b)
+	switch {
+	case bits.HasLine('B', rules.WinLength):
+		return true, 'B'
+	case bits.HasLine('W', rules.WinLength):
+		return true, 'W'
+	}
+	return IsBoardFull(b), ' '
+}
+
+// LegalMoveCount cuenta los movimientos legales del próximo turno
+// Parámetros:
+// - b: Tablero actual
+// Retorna: Las casillas vacías en la apertura (una piedra); en otro caso los
+// pares de casillas vacías distintas, sin importar el orden
+func LegalMoveCount(b Board) int {
+	empties := BoardSize*BoardSize - StoneCount(b)
+	if MoveStoneCount(b) == 1 {
+		return empties
+	}
+	return empties * (empties - 1) / 2
+}
+
 // BoardHash genera un identificador (string) para el estado del tablero
 // para usar en tablas de transposición o almacenamiento.
 func BoardHash(b Board) string {
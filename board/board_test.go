@@ -0,0 +1,447 @@
+package board
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// TestWinningMovesKeepExactLength comprueba que, cuando basta una piedra para
+// ganar, la segunda no prolonga la línea: con ExactWinLength cada victoria
+// inmediata que se propone sigue ganando
+func TestWinningMovesKeepExactLength(t *testing.T) {
+	exact := Rules{WinLength: WinLength, ExactWinLength: true}
+	var b Board
+	// Cinco negras desde (0,1): los huecos (0,0) y (0,6) ganan con una piedra,
+	// y la primera casilla libre tras (0,0) es justamente la prolongación (0,6)
+	for c := 1; c <= 5; c++ {
+		b[0][c] = 'B'
+	}
+	b[9][9] = 'W'
+	b[9][10] = 'W'
+
+	wins := exact.FindAllWinningMoves(b, 'B')
+	if len(wins) == 0 {
+		t.Fatal("no se encontró ninguna victoria inmediata")
+	}
+	for _, move := range wins {
+		after := b
+		ApplyMove(&after, move, 'B')
+		if !exact.CheckWin(after, 'B') {
+			t.Errorf("%v forma una sobrelínea en lugar de ganar", move)
+		}
+	}
+}
+
+// TestExactWinLengthRejectsOverline: seis en línea gana con ambas reglas y
+// siete solo sin ExactWinLength, en CheckWin, IsTerminal, GetWinner y WinningLine
+func TestExactWinLengthRejectsOverline(t *testing.T) {
+	exact := Rules{WinLength: WinLength, ExactWinLength: true}
+	var six, seven Board
+	for c := 0; c < 6; c++ {
+		six[3][c] = 'B'
+		seven[3][c] = 'B'
+	}
+	seven[3][6] = 'B'
+
+	for _, rules := range []Rules{DefaultRules, exact} {
+		if !rules.CheckWin(six, 'B') {
+			t.Errorf("exact=%v: seis en línea no gana", rules.ExactWinLength)
+		}
+		if over, winner := rules.IsTerminal(six); !over || winner != 'B' {
+			t.Errorf("exact=%v: IsTerminal(seis) = %v, %q", rules.ExactWinLength, over, winner)
+		}
+	}
+
+	if over, winner := DefaultRules.IsTerminal(seven); !over || winner != 'B' {
+		t.Errorf("sobrelínea permitida: IsTerminal = %v, %q, want true, 'B'", over, winner)
+	}
+	if len(DefaultRules.WinningLine(seven, 'B')) != 7 {
+		t.Error("sobrelínea permitida: WinningLine no retorna las siete piedras")
+	}
+	if exact.CheckWin(seven, 'B') || exact.GetWinner(seven) != ' ' {
+		t.Error("ExactWinLength: siete en línea gana")
+	}
+	if over, _ := exact.IsTerminal(seven); over {
+		t.Error("ExactWinLength: IsTerminal da por terminada una sobrelínea")
+	}
+	if exact.WinningLine(seven, 'B') != nil {
+		t.Error("ExactWinLength: WinningLine retorna una sobrelínea")
+	}
+	if exact.MoveWins(seven, Move{{Row: 3, Col: 6}, NoPosition}, 'B') {
+		t.Error("ExactWinLength: MoveWins acepta la piedra que forma la sobrelínea")
+	}
+}
+
+// TestCheckWinAtMatchesCheckWin: hay una línea ganadora en el tablero si y
+// solo si CheckWinAt la encuentra a través de alguna de las piedras del jugador
+func TestCheckWinAtMatchesCheckWin(t *testing.T) {
+	rng := rand.New(rand.NewSource(25))
+	for i := 0; i < 500; i++ {
+		b := randomBoard(rng, 0.2+0.6*rng.Float64())
+		for _, player := range []rune{'B', 'W'} {
+			at := false
+			for r := 0; r < BoardSize && !at; r++ {
+				for c := 0; c < BoardSize && !at; c++ {
+					at = CheckWinAt(b, Position{Row: r, Col: c}, player)
+				}
+			}
+			if want := CheckWin(b, player); at != want {
+				t.Fatalf("posición %d, %q: CheckWinAt = %v, CheckWin = %v\n%s", i, player, at, want, Serialize(b))
+			}
+		}
+	}
+}
+
+// TestPriorityPositionsStableOrder: GetPriorityPositions y GenerateSmartMoves
+// dan el mismo orden en cada llamada, con las posiciones por fila y columna
+func TestPriorityPositionsStableOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(56))
+	for i := 0; i < 20; i++ {
+		b := randomBoard(rng, 0.05)
+		first := GetPriorityPositions(b, 2)
+		for j := 1; j < len(first); j++ {
+			prev, p := first[j-1], first[j]
+			if p.Row < prev.Row || (p.Row == prev.Row && p.Col <= prev.Col) {
+				t.Fatalf("posición %d: %v va después de %v", i, p, prev)
+			}
+		}
+		moves := GenerateSmartMoves(b)
+		for k := 0; k < 5; k++ {
+			if again := GetPriorityPositions(b, 2); !reflect.DeepEqual(again, first) {
+				t.Fatalf("posición %d: GetPriorityPositions cambió de orden", i)
+			}
+			if again := GenerateSmartMoves(b); !reflect.DeepEqual(again, moves) {
+				t.Fatalf("posición %d: GenerateSmartMoves cambió de orden", i)
+			}
+		}
+	}
+}
+
+// TestCellAccessorsOutOfRange: Get y Set rechazan posiciones fuera del tablero
+// sin entrar en pánico, y ApplyMove no coloca ninguna piedra de un movimiento así
+func TestCellAccessorsOutOfRange(t *testing.T) {
+	var b Board
+	for _, p := range []Position{{-1, 0}, {0, -1}, {BoardSize, 0}, {0, BoardSize}, NoPosition} {
+		if _, ok := Get(b, p); ok {
+			t.Errorf("Get(%v) dentro del tablero", p)
+		}
+		if Set(&b, p, 'B') {
+			t.Errorf("Set(%v) dentro del tablero", p)
+		}
+	}
+	if !Set(&b, Position{Row: 18, Col: 18}, 'W') {
+		t.Fatal("Set(18,18) falló")
+	}
+	if v, ok := Get(b, Position{Row: 18, Col: 18}); !ok || v != 'W' {
+		t.Errorf("Get(18,18) = %q, %v", v, ok)
+	}
+
+	before := b
+	if ApplyMove(&b, Move{{Row: 3, Col: 3}, {Row: 3, Col: BoardSize}}, 'B') {
+		t.Error("ApplyMove aceptó una posición fuera del tablero")
+	}
+	if b != before {
+		t.Error("ApplyMove colocó piedras de un movimiento fuera del tablero")
+	}
+}
+
+// TestApplyMoveCheckedRejectsIllegal: casillas ocupadas, fuera del tablero,
+// repetidas o con el número de piedras equivocado dan error sin tocar el tablero
+func TestApplyMoveCheckedRejectsIllegal(t *testing.T) {
+	p := func(r, c int) Position { return Position{Row: r, Col: c} }
+	var opening Board
+	if err := ApplyMoveChecked(&opening, Move{p(9, 9), p(9, 10)}, 'B'); err == nil {
+		t.Error("apertura de dos piedras aceptada")
+	}
+	if err := ApplyMoveChecked(&opening, Move{p(9, 9), NoPosition}, 'B'); err != nil || opening[9][9] != 'B' {
+		t.Fatalf("apertura legal: %v", err)
+	}
+
+	for _, move := range []Move{
+		{p(9, 9), p(0, 0)},    // ocupada
+		{p(0, 0), p(9, 9)},    // ocupada (segunda piedra)
+		{p(0, 0), p(-1, 3)},   // fuera del tablero
+		{p(19, 0), p(0, 0)},   // fuera del tablero
+		{p(0, 0), p(0, 0)},    // repetida
+		{p(0, 0), NoPosition}, // una sola piedra tras la apertura
+	} {
+		b := opening
+		if err := ApplyMoveChecked(&b, move, 'W'); err == nil {
+			t.Errorf("%v aceptado", move)
+		}
+		if b != opening {
+			t.Errorf("%v modificó el tablero", move)
+		}
+	}
+}
+
+// TestIsTerminalAndLegalMoveCount cubre una partida ganada, una en empate
+// (tablero lleno sin línea) y una en curso
+func TestIsTerminalAndLegalMoveCount(t *testing.T) {
+	const cells = BoardSize * BoardSize
+
+	var empty Board
+	if over, winner := IsTerminal(empty); over || winner != ' ' {
+		t.Errorf("tablero vacío: IsTerminal = %v, %q", over, winner)
+	}
+	if n := LegalMoveCount(empty); n != cells {
+		t.Errorf("tablero vacío: %d movimientos, want %d", n, cells)
+	}
+
+	ongoing := empty
+	ongoing[9][9] = 'B'
+	ongoing[9][10] = 'W'
+	ongoing[10][10] = 'W'
+	if over, _ := IsTerminal(ongoing); over {
+		t.Error("partida en curso marcada como terminada")
+	}
+	if n, free := LegalMoveCount(ongoing), cells-3; n != free*(free-1)/2 {
+		t.Errorf("partida en curso: %d movimientos, want %d", n, free*(free-1)/2)
+	}
+
+	won := ongoing
+	for r := 0; r < WinLength; r++ {
+		won[r][0] = 'W'
+	}
+	if over, winner := IsTerminal(won); !over || winner != 'W' {
+		t.Errorf("victoria blanca: IsTerminal = %v, %q", over, winner)
+	}
+
+	// Tablero lleno sin ganador: columnas alternadas de dos en dos
+	var full Board
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			full[r][c] = 'B'
+			if (c/2+r)%2 == 1 {
+				full[r][c] = 'W'
+			}
+		}
+	}
+	if over, winner := IsTerminal(full); !over || winner != ' ' {
+		t.Errorf("empate: IsTerminal = %v, %q", over, winner)
+	}
+	if n := LegalMoveCount(full); n != 0 {
+		t.Errorf("empate: %d movimientos legales", n)
+	}
+}
+
+// TestGetCurrentPlayerConnect6Turns: negras abren con una piedra y luego cada
+// bando coloca dos, así que el turno cambia cada dos piedras tras la apertura
+func TestGetCurrentPlayerConnect6Turns(t *testing.T) {
+	var b Board
+	want := []rune{'B', 'W', 'W', 'B', 'B', 'W', 'W', 'B', 'B', 'W', 'W'}
+	for stones, player := range want {
+		if got := GetCurrentPlayer(b); got != player {
+			t.Errorf("%d piedras: GetCurrentPlayer = %q, want %q", stones, got, player)
+		}
+		// Coloca la siguiente piedra del jugador al que le toca
+		b[stones/BoardSize][stones%BoardSize] = player
+	}
+}
+
+// TestEmptyBoardGeneratesSingleStones: en la apertura GenerateSmartMoves solo
+// propone movimientos de una piedra (segunda = NoPosition), todos legales
+func TestEmptyBoardGeneratesSingleStones(t *testing.T) {
+	var b Board
+	moves := GenerateSmartMoves(b)
+	if len(moves) == 0 {
+		t.Fatal("no hay movimientos de apertura")
+	}
+	for _, move := range moves {
+		if move[1] != NoPosition || !IsValidMove(b, move[0], move[1]) {
+			t.Errorf("apertura %v no es de una sola piedra legal", move)
+		}
+	}
+	if win := FindWinningMove(b, 'B'); win != nil {
+		t.Errorf("FindWinningMove en el tablero vacío: %v", *win)
+	}
+
+	b[9][9] = 'B'
+	for _, move := range GenerateSmartMoves(b) {
+		if move[1] == NoPosition {
+			t.Fatalf("tras la apertura se propuso una sola piedra: %v", move)
+		}
+	}
+}
+
+// TestFindAllWinningMovesListsEach: con dos cincos distintos que se completan
+// en casillas distintas se retornan ambas victorias, y cada una gana
+func TestFindAllWinningMovesListsEach(t *testing.T) {
+	var b Board
+	for c := 1; c <= 5; c++ {
+		b[2][c] = 'B'
+		b[12][c] = 'B'
+	}
+	b[2][0], b[12][0] = 'W', 'W' // cada cinco solo se completa por la derecha
+	for c := 0; c < 8; c++ {
+		b[17][c*2] = 'W'
+	}
+
+	wins := FindAllWinningMoves(b, 'B')
+	if len(wins) < 2 {
+		t.Fatalf("FindAllWinningMoves = %v, want al menos 2 victorias", wins)
+	}
+	found := map[Position]bool{}
+	for _, move := range wins {
+		after := b
+		ApplyMove(&after, move, 'B')
+		if !CheckWin(after, 'B') {
+			t.Errorf("%v no gana", move)
+		}
+		for _, p := range move {
+			found[p] = true
+		}
+	}
+	if !found[Position{2, 6}] || !found[Position{12, 6}] {
+		t.Errorf("FindAllWinningMoves = %v, want una victoria por (2,6) y otra por (12,6)", wins)
+	}
+}
+
+// TestAdaptiveRadiusWidensSparseBoards: con una sola piedra el radio crece hasta
+// el máximo; con muchas piedras se queda en DefaultRadius
+func TestAdaptiveRadiusWidensSparseBoards(t *testing.T) {
+	// reach retorna la mayor distancia de una candidata a su piedra más cercana
+	reach := func(b Board, positions []Position) int {
+		max := 0
+		for _, p := range positions {
+			d := 0
+			for !hasStoneWithin(&b, p, d) {
+				d++
+			}
+			if d > max {
+				max = d
+			}
+		}
+		return max
+	}
+
+	var sparse Board
+	sparse[9][9] = 'B'
+	var dense Board
+	for r := 6; r <= 12; r += 2 {
+		for c := 6; c <= 12; c += 2 {
+			dense[r][c] = 'W'
+		}
+	}
+
+	if got := reach(sparse, GetPriorityPositionsAdaptive(sparse)); got != maxAdaptiveRadius {
+		t.Errorf("tablero disperso: radio %d, want %d", got, maxAdaptiveRadius)
+	}
+	if got := reach(dense, GetPriorityPositionsAdaptive(dense)); got != DefaultRadius {
+		t.Errorf("tablero denso: radio %d, want %d", got, DefaultRadius)
+	}
+	if len(GetPriorityPositionsAdaptive(sparse)) <= len(GetPriorityPositions(sparse, DefaultRadius)) {
+		t.Error("el tablero disperso no amplió sus candidatas")
+	}
+}
+
+// TestEvaluateBoardScoresChainOnce: un cuatro horizontal suma un solo
+// WeightedChainScore(4, ...), no uno por cada piedra de la cadena. Las piedras
+// sueltas y las bonificaciones valen 0 para aislar la cadena
+func TestEvaluateBoardScoresChainOnce(t *testing.T) {
+	w := DefaultWeights
+	w.Single = 0
+	w.DoubleFour = 0
+	w.FourFour, w.FourThree, w.ThreeThree = 0, 0, 0
+	rules := DefaultRules.WithWeights(w)
+
+	var b Board
+	for c := 7; c < 11; c++ {
+		b[9][c] = 'B' // cuatro abierto de (9,7) a (9,10)
+	}
+
+	want := float64(rules.WeightedChainScore(4, false, false))
+	if want == 0 {
+		t.Fatal("el cuatro abierto no puntúa")
+	}
+	if got := rules.EvaluateBoard(b, 'B'); got != want {
+		t.Errorf("EvaluateBoard('B') = %.0f, want %.0f (una sola cadena)", got, want)
+	}
+	if got := rules.EvaluateBoard(b, 'W'); got != -want {
+		t.Errorf("EvaluateBoard('W') = %.0f, want %.0f", got, -want)
+	}
+}
+
+// TestWinningLineDiagonals: WinningLine retorna las seis posiciones exactas
+// en las dos diagonales, también cuando la línea toca el borde del tablero
+func TestWinningLineDiagonals(t *testing.T) {
+	line := func(r, c, dr, dc int) []Position {
+		var ps []Position
+		for i := 0; i < WinLength; i++ {
+			ps = append(ps, Position{Row: r + i*dr, Col: c + i*dc})
+		}
+		return ps
+	}
+	for name, want := range map[string][]Position{
+		"diagonal \\":          line(4, 3, 1, 1),
+		"diagonal /":           line(2, 12, 1, -1),
+		"diagonal \\ al borde": line(13, 13, 1, 1), // termina en (18,18)
+		"diagonal / al borde":  line(0, 18, 1, -1), // empieza en la esquina (0,18)
+		"diagonal / abajo":     line(13, 5, 1, -1), // termina en (18,0)
+	} {
+		var b Board
+		for _, p := range want {
+			b[p.Row][p.Col] = 'W'
+		}
+		// Piedras blancas sueltas y negras junto a la línea que no la alargan
+		b[9][9] = 'W'
+		first, last := want[0], want[len(want)-1]
+		if r, c := 2*first.Row-want[1].Row, 2*first.Col-want[1].Col; r >= 0 && r < BoardSize && c >= 0 && c < BoardSize {
+			b[r][c] = 'B'
+		}
+		if r, c := 2*last.Row-want[len(want)-2].Row, 2*last.Col-want[len(want)-2].Col; r >= 0 && r < BoardSize && c >= 0 && c < BoardSize {
+			b[r][c] = 'B'
+		}
+
+		if got := WinningLine(b, 'W'); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: WinningLine = %v, want %v", name, got, want)
+		}
+		if got := WinningLine(b, 'B'); got != nil {
+			t.Errorf("%s: las negras no ganan y WinningLine = %v", name, got)
+		}
+	}
+}
+
+// TestCountOpenFours: solo cuenta los cuatros seguidos con ambos extremos
+// libres; los cerrados, los del borde y los partidos no cuentan, y dos
+// cuatros abiertos suman la bonificación DoubleFour en EvaluateBoard
+func TestCountOpenFours(t *testing.T) {
+	four := func(b *Board, r, c int) {
+		for i := 0; i < 4; i++ {
+			b[r][c+i] = 'B'
+		}
+	}
+	cases := []struct {
+		name  string
+		setup func(b *Board)
+		want  int
+	}{
+		{"abierto", func(b *Board) { four(b, 5, 5) }, 1},
+		{"semiabierto", func(b *Board) { four(b, 5, 5); b[5][4] = 'W' }, 0},
+		{"cerrado", func(b *Board) { four(b, 5, 5); b[5][4], b[5][9] = 'W', 'W' }, 0},
+		{"contra el borde", func(b *Board) { four(b, 5, 0) }, 0},
+		{"partido BB.BB", func(b *Board) { b[5][5], b[5][6], b[5][8], b[5][9] = 'B', 'B', 'B', 'B' }, 0},
+		{"doble cuatro", func(b *Board) { four(b, 5, 5); four(b, 12, 3) }, 2},
+	}
+	for _, tc := range cases {
+		var b Board
+		tc.setup(&b)
+		if got := CountOpenFours(b, 'B'); got != tc.want {
+			t.Errorf("%s: CountOpenFours = %d, want %d", tc.name, got, tc.want)
+		}
+		if got := CountOpenFours(b, 'W'); got != 0 {
+			t.Errorf("%s: CountOpenFours de las blancas = %d", tc.name, got)
+		}
+	}
+
+	var b Board
+	four(&b, 5, 5)
+	four(&b, 12, 3)
+	noBonus := DefaultWeights
+	noBonus.DoubleFour = 0
+	diff := EvaluateBoard(b, 'B') - DefaultRules.WithWeights(noBonus).EvaluateBoard(b, 'B')
+	if diff != float64(DefaultWeights.DoubleFour) {
+		t.Errorf("doble cuatro: la bonificación es %.0f, want %d", diff, DefaultWeights.DoubleFour)
+	}
+}
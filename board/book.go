@@ -0,0 +1,110 @@
+package board
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LoadOpeningBook lee un libro de aperturas
+// Formato (una entrada por línea; las vacías y las que empiezan con '#' se ignoran):
+//
+//	<tablero de Serialize> fila columna                     <- apertura de una piedra
+//	<tablero de Serialize> fila1 columna1 fila2 columna2
+//
+// Parámetros:
+// - path: Ruta del archivo del libro
+// Retorna: Mapa hash Zobrist => movimiento, o error si alguna línea es inválida
+// Nota: las claves dependen de ZobristInit; no guardar el mapa entre procesos
+func LoadOpeningBook(path string) (map[uint64]Move, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	book := make(map[uint64]Move)
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		b, move, err := parseBookLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("línea %d: %v", lineNo, err)
+		}
+		book[ZobristHash(b)] = move
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+// parseBookLine interpreta una entrada "tablero movimiento" y valida que el movimiento sea legal
+func parseBookLine(line string) (Board, Move, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 3 && len(fields) != 5 {
+		return Board{}, Move{}, fmt.Errorf("se esperaban un tablero y 2 o 4 coordenadas")
+	}
+	b, err := Parse(fields[0])
+	if err != nil {
+		return Board{}, Move{}, err
+	}
+
+	coords := make([]int, len(fields)-1)
+	for i, f := range fields[1:] {
+		if coords[i], err = strconv.Atoi(f); err != nil {
+			return Board{}, Move{}, fmt.Errorf("coordenada inválida %q", f)
+		}
+	}
+	move := Move{{Row: coords[0], Col: coords[1]}, NoPosition}
+	if len(coords) == 4 {
+		move[1] = Position{Row: coords[2], Col: coords[3]}
+	}
+	if !IsValidMove(b, move[0], move[1]) {
+		return Board{}, Move{}, fmt.Errorf("movimiento ilegal para el tablero")
+	}
+	return b, move, nil
+}
+
+// BookMove busca la posición en el libro, también girada o reflejada
+// Parámetros:
+// - book: Libro de LoadOpeningBook (puede ser nil)
+// - b: Tablero actual
+// Retorna: El movimiento del libro llevado a la orientación de 'b' y true, o
+// false si ninguna de las 8 simetrías está en el libro con un movimiento legal
+// (una entrada ilegal se salta y se prueba la siguiente simetría)
+func BookMove(book map[uint64]Move, b Board) (Move, bool) {
+	if len(book) == 0 {
+		return Move{}, false
+	}
+	for k := 0; k < 8; k++ {
+		move, ok := book[ZobristHash(transformBoard(b, k))]
+		if !ok {
+			continue
+		}
+		// El movimiento es de la posición transformada: se deshace la simetría
+		inv := inverseSymmetry(k)
+		move = Move{TransformPosition(move[0], inv), TransformPosition(move[1], inv)}
+		if IsValidMove(b, move[0], move[1]) {
+			return move, true
+		}
+	}
+	return Move{}, false
+}
+
+// inverseSymmetry retorna la simetría que deshace la 'k' de TransformPosition:
+// los giros se deshacen girando el resto de la vuelta y las reflexiones son
+// su propia inversa
+func inverseSymmetry(k int) int {
+	if k&4 != 0 {
+		return k
+	}
+	return (4 - k) % 4
+}
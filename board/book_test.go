@@ -0,0 +1,68 @@
+package board
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestOpeningBookLookup: la posición del libro da su movimiento, también
+// girada o reflejada (con el movimiento llevado a esa orientación); una
+// entrada ilegal para la posición se salta y el archivo con una línea ilegal
+// se rechaza
+func TestOpeningBookLookup(t *testing.T) {
+	var known Board
+	known[9][9] = 'B'
+	known[9][10], known[10][10] = 'W', 'W'
+	reply := Move{{Row: 8, Col: 11}, {Row: 11, Col: 8}}
+
+	path := filepath.Join(t.TempDir(), "libro.txt")
+	content := "# libro de prueba\n\n" + Serialize(known) + " 8 11 11 8\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	book, err := LoadOpeningBook(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if move, ok := BookMove(book, known); !ok || move != reply {
+		t.Errorf("posición del libro: BookMove = %v, %v; want %v", move, ok, reply)
+	}
+
+	n := BoardSize - 1
+	rotated := Rotate90(known) // (fila, col) => (col, n-fila)
+	wantRotated := Move{{Row: 11, Col: n - 8}, {Row: 8, Col: n - 11}}
+	if move, ok := BookMove(book, rotated); !ok || normalizePair(move) != normalizePair(wantRotated) {
+		t.Errorf("girada 90°: BookMove = %v, %v; want %v", move, ok, wantRotated)
+	}
+	mirrored := Reflect(known) // (fila, col) => (fila, n-col)
+	wantMirrored := Move{{Row: 8, Col: n - 11}, {Row: 11, Col: n - 8}}
+	if move, ok := BookMove(book, mirrored); !ok || normalizePair(move) != normalizePair(wantMirrored) {
+		t.Errorf("reflejada: BookMove = %v, %v; want %v", move, ok, wantMirrored)
+	}
+
+	other := known
+	other[0][0] = 'B'
+	if move, ok := BookMove(book, other); ok {
+		t.Errorf("posición fuera del libro: BookMove = %v", move)
+	}
+
+	// Una entrada que ocupa una casilla llena no se juega
+	illegal := map[uint64]Move{ZobristHash(known): {{Row: 9, Col: 9}, {Row: 0, Col: 0}}}
+	if move, ok := BookMove(illegal, known); ok {
+		t.Errorf("entrada ilegal: BookMove = %v", move)
+	}
+	if _, ok := BookMove(nil, known); ok {
+		t.Error("libro nil: BookMove encontró un movimiento")
+	}
+
+	bad := filepath.Join(t.TempDir(), "malo.txt")
+	if err := os.WriteFile(bad, []byte(Serialize(known)+" 9 9 0 0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadOpeningBook(bad); err == nil || !strings.Contains(err.Error(), "línea 1") {
+		t.Errorf("libro con una jugada ilegal: err = %v", err)
+	}
+}
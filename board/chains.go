@@ -0,0 +1,117 @@
+package board
+
+// ChainRun describe una cadena máxima de piedras consecutivas de un jugador
+// en una de las 4 direcciones (horizontal, vertical y ambas diagonales)
+type ChainRun struct {
+	Player       rune
+	Start        Position // primera piedra de la cadena
+	DR, DC       int      // dirección de avance desde Start
+	Length       int
+	BlockedStart bool // la celda anterior a Start es rival o fuera del tablero
+	BlockedEnd   bool // la celda posterior a la última piedra es rival o fuera del tablero
+}
+
+// chainDirections son las 4 direcciones en que se buscan cadenas
+var chainDirections = []struct{ dr, dc int }{
+	{0, 1},  // Horizontal
+	{1, 0},  // Vertical
+	{1, 1},  // Diagonal \
+	{1, -1}, // Diagonal /
+}
+
+// ChainRuns obtiene todas las cadenas máximas del tablero (de ambos jugadores)
+// Parámetros:
+// - b: Tablero actual
+// Retorna: Una entrada por cadena y dirección; las piedras sueltas aparecen
+// como cadenas de longitud 1 en cada dirección. Los extremos se evalúan en
+// las celdas que rodean a la cadena completa, no en la piedra inicial.
+func ChainRuns(b Board) []ChainRun {
+	inRange := func(r, c int) bool {
+		return r >= 0 && r < BoardSize && c >= 0 && c < BoardSize
+	}
+	// blocked: fuera del tablero o piedra distinta de vacío
+	blocked := func(r, c int) bool {
+		return !inRange(r, c) || b[r][c] != '\x00'
+	}
+
+	var runs []ChainRun
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			player := b[r][c]
+			if player == '\x00' {
+				continue
+			}
+			for _, d := range chainDirections {
+				// Solo desde la primera piedra: así cada cadena se cuenta una vez
+				pr, pc := r-d.dr, c-d.dc
+				if inRange(pr, pc) && b[pr][pc] == player {
+					continue
+				}
+
+				length := 1
+				for inRange(r+d.dr*length, c+d.dc*length) && b[r+d.dr*length][c+d.dc*length] == player {
+					length++
+				}
+
+				runs = append(runs, ChainRun{
+					Player:       player,
+					Start:        Position{r, c},
+					DR:           d.dr,
+					DC:           d.dc,
+					Length:       length,
+					BlockedStart: blocked(pr, pc),
+					BlockedEnd:   blocked(r+d.dr*length, c+d.dc*length),
+				})
+			}
+		}
+	}
+	return runs
+}
+
+// QuickMoveScore estima el valor de 'move' para 'player' sin evaluar todo el tablero
+// Suma WeightedChainScore de las cadenas que atraviesan cada piedra nueva (ataque)
+// y de las cadenas rivales que esa casilla interrumpe (defensa)
+// Parámetros:
+// - b: Tablero antes del movimiento
+// - move: Movimiento a puntuar (una o dos piedras)
+// - player: Jugador que mueve
+// Retorna: Puntaje heurístico (mayor = mejor)
+func QuickMoveScore(b Board, move Move, player rune) int {
+	return DefaultRules.QuickMoveScore(b, move, player)
+}
+
+// QuickMoveScore es QuickMoveScore con los pesos y el WinLength de estas reglas
+func (rules Rules) QuickMoveScore(b Board, move Move, player rune) int {
+	opponent := SwitchPlayer(player)
+	after := b
+	ApplyMove(&after, move, player)
+
+	score := 0
+	for _, p := range move {
+		if p == NoPosition {
+			continue
+		}
+		blocked := b
+		blocked[p.Row][p.Col] = opponent
+		for _, d := range chainDirections {
+			score += rules.chainScoreThrough(after, p, d.dr, d.dc, player)
+			score += rules.chainScoreThrough(blocked, p, d.dr, d.dc, opponent)
+		}
+	}
+	return score
+}
+
+// chainScoreThrough puntúa la cadena de 'player' que pasa por 'p' en la dirección (dr,dc)
+func (rules Rules) chainScoreThrough(b Board, p Position, dr, dc int, player rune) int {
+	length := 1
+	blockedEnds := [2]bool{}
+	for i, sign := range []int{1, -1} {
+		r, c := p.Row+sign*dr, p.Col+sign*dc
+		for r >= 0 && r < BoardSize && c >= 0 && c < BoardSize && b[r][c] == player {
+			length++
+			r, c = r+sign*dr, c+sign*dc
+		}
+		blockedEnds[i] = r < 0 || r >= BoardSize || c < 0 || c >= BoardSize || b[r][c] != '\x00'
+	}
+	return rules.WeightedChainScore(length, blockedEnds[0], blockedEnds[1])
+}
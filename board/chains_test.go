@@ -0,0 +1,71 @@
+package board
+
+import (
+	"testing"
+)
+
+// TestChainRunsOpenEnds: los extremos se evalúan en las celdas que rodean a
+// la cadena completa (abierta, semiabierta, bloqueada por el rival o por el
+// borde), y un hueco separa dos cadenas abiertas hacia él
+func TestChainRunsOpenEnds(t *testing.T) {
+	var b Board
+	for c := 5; c <= 7; c++ {
+		b[9][c] = 'B' // abierta
+	}
+	for c := 0; c <= 2; c++ {
+		b[3][c] = 'W' // contra el borde izquierdo
+	}
+	for c := 5; c <= 8; c++ {
+		b[6][c] = 'B' // cerrada al final por (6,9)
+	}
+	b[6][9] = 'W'
+	b[12][3], b[12][4], b[12][5], b[12][6] = 'W', 'B', 'B', 'W' // bloqueada en ambos extremos
+	b[15][2], b[15][3], b[15][5] = 'B', 'B', 'B'                // "BB.B"
+	for i := 0; i < 3; i++ {
+		b[i][12+i] = 'W' // diagonal \ desde el borde superior
+	}
+
+	cases := []struct {
+		name                     string
+		player                   rune
+		start                    Position
+		dr, dc                   int
+		length                   int
+		blockedStart, blockedEnd bool
+	}{
+		{"abierta", 'B', Position{9, 5}, 0, 1, 3, false, false},
+		{"borde", 'W', Position{3, 0}, 0, 1, 3, true, false},
+		{"cerrada al final", 'B', Position{6, 5}, 0, 1, 4, false, true},
+		{"bloqueada", 'B', Position{12, 4}, 0, 1, 2, true, true},
+		{"antes del hueco", 'B', Position{15, 2}, 0, 1, 2, false, false},
+		{"tras el hueco", 'B', Position{15, 5}, 0, 1, 1, false, false},
+		{"diagonal", 'W', Position{0, 12}, 1, 1, 3, true, false},
+	}
+
+	runs := ChainRuns(b)
+	for _, tc := range cases {
+		var found *ChainRun
+		for i := range runs {
+			if r := &runs[i]; r.Start == tc.start && r.DR == tc.dr && r.DC == tc.dc {
+				found = r
+			}
+		}
+		if found == nil {
+			t.Errorf("%s: no hay cadena desde %v en (%d,%d)", tc.name, tc.start, tc.dr, tc.dc)
+			continue
+		}
+		if found.Player != tc.player || found.Length != tc.length {
+			t.Errorf("%s: %q de longitud %d, want %q de %d", tc.name, found.Player, found.Length, tc.player, tc.length)
+		}
+		if found.BlockedStart != tc.blockedStart || found.BlockedEnd != tc.blockedEnd {
+			t.Errorf("%s: bloqueos (%v, %v), want (%v, %v)", tc.name, found.BlockedStart, found.BlockedEnd, tc.blockedStart, tc.blockedEnd)
+		}
+	}
+
+	// Ninguna cadena empieza dentro de otra: las piedras interiores no generan entradas
+	for _, r := range runs {
+		if r.Start == (Position{9, 6}) && r.DC == 1 && r.DR == 0 {
+			t.Errorf("cadena desde una piedra interior: %+v", r)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package board
+
+import (
+	"fmt"
+)
+
+// EmptySet es el conjunto de casillas vacías de un tablero, mantenido de forma
+// incremental: Contains, Add y Remove son O(1), y Positions recorre solo las
+// vacías, sin escanear las BoardSize*BoardSize casillas
+// Quien lo usa debe actualizarlo con cada cambio del tablero (Apply, o
+// ApplyMoveIn que hace ambas cosas)
+type EmptySet struct {
+	cells []Position                // casillas vacías, sin orden
+	index [BoardSize][BoardSize]int // índice+1 de cada casilla en 'cells' (0 = ocupada)
+}
+
+// NewEmptySet crea el conjunto con las casillas vacías de 'b'
+func NewEmptySet(b Board) *EmptySet {
+	s := &EmptySet{}
+	s.Reset(b)
+	return s
+}
+
+// Reset reconstruye el conjunto desde 'b' (p.ej. tras cargar otra posición)
+func (s *EmptySet) Reset(b Board) {
+	s.cells = s.cells[:0]
+	s.index = [BoardSize][BoardSize]int{}
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			if b[r][c] == '\x00' {
+				s.Add(Position{r, c})
+			}
+		}
+	}
+}
+
+// Len retorna el número de casillas vacías
+func (s *EmptySet) Len() int {
+	return len(s.cells)
+}
+
+// Contains indica si 'p' está dentro del tablero y vacía
+func (s *EmptySet) Contains(p Position) bool {
+	return inBounds(p) && s.index[p.Row][p.Col] != 0
+}
+
+// Positions retorna las casillas vacías en un orden arbitrario
+// El slice es del conjunto: no se debe modificar y cambia con Add/Remove
+func (s *EmptySet) Positions() []Position {
+	return s.cells
+}
+
+// Add marca 'p' como vacía; no hace nada si ya lo estaba o está fuera del tablero
+func (s *EmptySet) Add(p Position) {
+	if !inBounds(p) || s.index[p.Row][p.Col] != 0 {
+		return
+	}
+	s.cells = append(s.cells, p)
+	s.index[p.Row][p.Col] = len(s.cells)
+}
+
+// Remove marca 'p' como ocupada; la última casilla ocupa su hueco en 'cells'
+func (s *EmptySet) Remove(p Position) {
+	if !s.Contains(p) {
+		return
+	}
+	i := s.index[p.Row][p.Col] - 1
+	last := s.cells[len(s.cells)-1]
+	s.cells[i] = last
+	s.index[last.Row][last.Col] = i + 1
+	s.cells = s.cells[:len(s.cells)-1]
+	s.index[p.Row][p.Col] = 0
+}
+
+// Apply quita del conjunto las piedras de 'move' (move[1] puede ser NoPosition)
+func (s *EmptySet) Apply(move Move) {
+	s.Remove(move[0])
+	if move[1] != NoPosition {
+		s.Remove(move[1])
+	}
+}
+
+// IsValidMoveIn es IsValidMove consultando 'empties' en lugar del tablero
+func IsValidMoveIn(empties *EmptySet, p1, p2 Position) bool {
+	return DefaultRules.IsValidMoveIn(empties, p1, p2)
+}
+
+// IsValidMoveIn valida un movimiento con el conjunto de casillas vacías
+// Parámetros:
+// - empties: Casillas vacías del tablero, al día
+// - p1, p2: Posiciones del movimiento (p2 = NoPosition solo en la apertura)
+// Retorna: Lo mismo que IsValidMove sobre el tablero, en O(1)
+func (rules Rules) IsValidMoveIn(empties *EmptySet, p1, p2 Position) bool {
+	if p1 == p2 {
+		return false
+	}
+	// Tablero vacío: apertura de una sola piedra
+	if empties.Len() == BoardSize*BoardSize {
+		return p2 == NoPosition && empties.Contains(p1)
+	}
+	return empties.Contains(p1) && empties.Contains(p2)
+}
+
+// ApplyMoveIn es ApplyMoveChecked manteniendo al día 'empties'
+func ApplyMoveIn(b *Board, empties *EmptySet, move Move, player rune) error {
+	return DefaultRules.ApplyMoveIn(b, empties, move, player)
+}
+
+// ApplyMoveIn coloca las piedras de 'move' si es legal y las quita de 'empties'
+// Parámetros:
+// - b: Puntero al tablero
+// - empties: Casillas vacías de 'b', al día
+// - move: Movimiento a realizar
+// - player: Jugador actual ('B' o 'W')
+// Retorna: error de ApplyMoveChecked si el movimiento es ilegal, o si el
+// conjunto y el tablero no coinciden sobre su legalidad; en ambos casos nada cambia
+func (rules Rules) ApplyMoveIn(b *Board, empties *EmptySet, move Move, player rune) error {
+	legal := IsValidMove(*b, move[0], move[1])
+	if rules.IsValidMoveIn(empties, move[0], move[1]) != legal {
+		return fmt.Errorf("el conjunto de casillas vacías no coincide con el tablero en %v", move)
+	}
+	if !legal {
+		// El tablero explica el motivo (y no cambia)
+		return ApplyMoveChecked(b, move, player)
+	}
+	ApplyMove(b, move, player)
+	empties.Apply(move)
+	return nil
+}
@@ -0,0 +1,108 @@
+package board
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// checkEmptySet compara el conjunto con las casillas vacías del tablero
+func checkEmptySet(t *testing.T, b Board, s *EmptySet) {
+	t.Helper()
+	want := 0
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			p := Position{r, c}
+			if empty := b[r][c] == '\x00'; s.Contains(p) != empty {
+				t.Fatalf("%v: Contains = %v, casilla vacía = %v", p, s.Contains(p), empty)
+			}
+			if b[r][c] == '\x00' {
+				want++
+			}
+		}
+	}
+	if s.Len() != want || len(s.Positions()) != want {
+		t.Fatalf("Len = %d, Positions = %d, want %d", s.Len(), len(s.Positions()), want)
+	}
+}
+
+// TestEmptySetStaysConsistent aplica una partida al azar con ApplyMoveIn y
+// comprueba el conjunto contra el tablero tras cada jugada
+func TestEmptySetStaysConsistent(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var b Board
+	s := NewEmptySet(b)
+	player := 'B'
+	for turn := 0; turn < 60; turn++ {
+		moves := GenerateSmartMoves(b)
+		if err := ApplyMoveIn(&b, s, moves[rng.Intn(len(moves))], player); err != nil {
+			t.Fatalf("turno %d: %v", turn, err)
+		}
+		checkEmptySet(t, b, s)
+		player = SwitchPlayer(player)
+	}
+	// Una jugada ilegal no cambia ninguno de los dos
+	before := b
+	if err := ApplyMoveIn(&b, s, Move{s.Positions()[0], s.Positions()[0]}, player); err == nil {
+		t.Fatal("ApplyMoveIn aceptó dos piedras en la misma casilla")
+	}
+	if b != before {
+		t.Fatal("una jugada ilegal cambió el tablero")
+	}
+	checkEmptySet(t, b, s)
+}
+
+// TestApplyMoveInRejectsStaleSet: si el conjunto y el tablero no coinciden
+// sobre la jugada, ApplyMoveIn falla sin aplicar nada
+func TestApplyMoveInRejectsStaleSet(t *testing.T) {
+	var b Board
+	b[9][9] = 'B'
+	s := NewEmptySet(b)
+	b[9][10] = 'W' // el conjunto no se enteró
+	move := Move{{9, 10}, {9, 11}}
+	if err := ApplyMoveIn(&b, s, move, 'W'); err == nil {
+		t.Fatal("ApplyMoveIn aceptó una casilla ocupada que el conjunto da por vacía")
+	}
+	if b[9][11] != '\x00' {
+		t.Fatal("el movimiento rechazado cambió el tablero")
+	}
+
+	b[9][10] = '\x00'
+	s.Remove(Position{9, 10}) // ahora el conjunto la da por ocupada
+	if err := ApplyMoveIn(&b, s, move, 'W'); err == nil {
+		t.Fatal("ApplyMoveIn aceptó una casilla vacía que el conjunto da por ocupada")
+	}
+	if b[9][10] != '\x00' || b[9][11] != '\x00' || !s.Contains(Position{9, 11}) {
+		t.Fatal("el movimiento rechazado cambió el tablero o el conjunto")
+	}
+}
+
+// TestGenerateSmartMovesInMatchesBoardScan: con el conjunto de casillas vacías
+// la generación da lo mismo que escaneando el tablero, lleno o casi vacío
+func TestGenerateSmartMovesInMatchesBoardScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	// Parejas alternas por fila (sin seis en línea) con algunos huecos
+	var dense Board
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			if rng.Float64() < 0.2 {
+				continue
+			}
+			dense[r][c] = 'B'
+			if (c/2+r)%2 != 0 {
+				dense[r][c] = 'W'
+			}
+		}
+	}
+	var sparse Board
+	sparse[9][9], sparse[9][10], sparse[10][9] = 'B', 'W', 'W'
+
+	for name, b := range map[string]Board{"lleno": dense, "casi vacío": sparse} {
+		for _, rules := range []Rules{DefaultRules, {WinLength: WinLength, AdaptiveRadius: true}} {
+			want := rules.GenerateSmartMoves(b)
+			if got := rules.GenerateSmartMovesIn(b, NewEmptySet(b)); !reflect.DeepEqual(got, want) {
+				t.Errorf("%s (adaptativo=%v): GenerateSmartMovesIn difiere de GenerateSmartMoves", name, rules.AdaptiveRadius)
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package board
+
+// endgameKey identifica una posición del solucionador: el tablero y quién mueve
+type endgameKey struct {
+	hash   uint64
+	player rune
+}
+
+// SolveEndgame resuelve por fuerza bruta una posición con pocas casillas vacías
+// Con tan pocas casillas la búsqueda completa (alfa-beta sobre todos los pares)
+// es factible y, a diferencia de MCTS, exacta
+// Parámetros:
+// - b: Tablero actual
+// - player: Jugador que mueve
+// - maxCells: Máximo de casillas vacías que se acepta resolver
+// Retorna: El ganador teórico con juego perfecto ('B', 'W' o ' ' si es empate)
+// y true; false si hay más de 'maxCells' casillas vacías
+func SolveEndgame(b Board, player rune, maxCells int) (rune, bool) {
+	_, winner, ok := SolveEndgameMove(b, player, maxCells)
+	return winner, ok
+}
+
+// SolveEndgameMove es SolveEndgame que además retorna el movimiento que logra
+// el resultado teórico para 'player' (Move{} si la partida ya terminó o no
+// hay movimientos legales)
+func SolveEndgameMove(b Board, player rune, maxCells int) (Move, rune, bool) {
+	return DefaultRules.SolveEndgameMove(b, player, maxCells)
+}
+
+// SolveEndgameMove resuelve el final decidiendo las victorias con estas reglas
+func (rules Rules) SolveEndgameMove(b Board, player rune, maxCells int) (Move, rune, bool) {
+	if over, winner := rules.IsTerminal(b); over {
+		return Move{}, winner, true
+	}
+	var empties []Position
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			if b[r][c] == '\x00' {
+				empties = append(empties, Position{r, c})
+			}
+		}
+	}
+	if len(empties) > maxCells {
+		return Move{}, ' ', false
+	}
+
+	var best Move
+	memo := make(map[endgameKey]int)
+	switch rules.solveEndgame(b, player, empties, memo, &best) {
+	case 1:
+		return best, player, true
+	case -1:
+		return best, SwitchPlayer(player), true
+	}
+	return best, ' ', true
+}
+
+// solveEndgame es el negamax con poda: 1 si 'player' gana, -1 si pierde, 0 empate
+// Como 1 es el mejor valor posible, la poda beta es cortar al encontrar una
+// victoria; así todo valor guardado en 'memo' es exacto
+// Sin movimientos legales (menos casillas de las que pide el turno) es empate
+// Si 'best' no es nil recibe el mejor movimiento
+func (rules Rules) solveEndgame(b Board, player rune, empties []Position, memo map[endgameKey]int, best *Move) int {
+	key := endgameKey{ZobristHash(b), player}
+	if v, ok := memo[key]; ok && best == nil {
+		return v
+	}
+
+	var moves []Move
+	if MoveStoneCount(b) == 1 {
+		for _, p := range empties {
+			moves = append(moves, Move{p, NoPosition})
+		}
+	} else {
+		for i := range empties {
+			for j := i + 1; j < len(empties); j++ {
+				moves = append(moves, Move{empties[i], empties[j]})
+			}
+		}
+	}
+
+	value := -2
+	if len(moves) == 0 {
+		value = 0
+	}
+	for _, move := range moves {
+		next := b
+		ApplyMove(&next, move, player)
+		v := 1
+		if !rules.MoveWins(next, move, player) {
+			v = -rules.solveEndgame(next, SwitchPlayer(player), remainingEmpties(empties, move), memo, nil)
+		}
+		if v > value {
+			value = v
+			if best != nil {
+				*best = move
+			}
+			if value == 1 {
+				break
+			}
+		}
+	}
+	memo[key] = value
+	return value
+}
+
+// remainingEmpties retorna 'empties' sin las piedras de 'move'
+func remainingEmpties(empties []Position, move Move) []Position {
+	rest := make([]Position, 0, len(empties))
+	for _, p := range empties {
+		if p != move[0] && p != move[1] {
+			rest = append(rest, p)
+		}
+	}
+	return rest
+}
@@ -0,0 +1,49 @@
+package board
+
+import (
+	"testing"
+)
+
+// drawnFill retorna un tablero lleno en franjas de dos piedras (ninguna línea pasa
+// de dos en ninguna dirección) con las casillas 'empty' vacías
+func drawnFill(empty ...Position) Board {
+	var b Board
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			b[r][c] = 'W'
+			if (c+2*r)%4 < 2 {
+				b[r][c] = 'B'
+			}
+		}
+	}
+	for _, p := range empty {
+		b[p.Row][p.Col] = '\x00'
+	}
+	return b
+}
+
+// TestSolveEndgameKnownResults: con un cinco negro que se completa en (9,8),
+// gana quien tenga el turno para decidirlo: las negras lo completan y las
+// blancas lo tapan (sin otras líneas, el resto es empate)
+func TestSolveEndgameKnownResults(t *testing.T) {
+	b := drawnFill(Position{9, 8}, Position{0, 0}, Position{18, 18}, Position{0, 18})
+	for c := 3; c <= 7; c++ {
+		b[9][c] = 'B'
+	}
+	b[9][2], b[9][9] = 'W', 'W' // el cinco solo se completa en (9,8)
+
+	if winner, ok := SolveEndgame(b, 'B', 10); !ok || winner != 'B' {
+		t.Errorf("mueven negras: SolveEndgame = %q, %v; want 'B', true", winner, ok)
+	}
+	if winner, ok := SolveEndgame(b, 'W', 10); !ok || winner != ' ' {
+		t.Errorf("mueven blancas: SolveEndgame = %q, %v; want ' ', true", winner, ok)
+	}
+	if _, ok := SolveEndgame(b, 'B', 3); ok {
+		t.Error("con 4 casillas vacías y maxCells 3 no debería resolver")
+	}
+
+	move, _, _ := SolveEndgameMove(b, 'B', 10)
+	if move[0] != (Position{9, 8}) && move[1] != (Position{9, 8}) {
+		t.Errorf("SolveEndgameMove = %v, want que complete en (9,8)", move)
+	}
+}
@@ -0,0 +1,207 @@
+package board
+
+// FindForcedWin busca una victoria forzada por amenazas (threat-space search)
+// Solo se prueban jugadas que crean una amenaza de victoria (una ventana de
+// WinLength con WinLength-2 piedras propias o más y sin piedras rivales) y, como
+// respuestas, los bloqueos que la neutralizan (ver defenseCandidates); las
+// contra-amenazas del rival solo se consideran si ganan de inmediato.
+// Parámetros:
+// - b: Tablero actual, con 'player' por mover
+// - player: Jugador atacante
+// - maxDepth: Máximo de turnos del atacante, contando la jugada ganadora
+// Retorna: La línea principal más corta (atacante, defensa, atacante, ..., jugada
+// ganadora) y true si el atacante gana contra cualquier defensa; nil y false en otro caso
+func FindForcedWin(b Board, player rune, maxDepth int) ([]Move, bool) {
+	if MoveStoneCount(b) == 1 {
+		return nil, false
+	}
+	for depth := 1; depth <= maxDepth; depth++ {
+		if line, ok := forcedWin(b, player, depth); ok {
+			return line, true
+		}
+	}
+	return nil, false
+}
+
+// forcedWin es el paso recursivo de FindForcedWin
+func forcedWin(b Board, player rune, depth int) ([]Move, bool) {
+	if depth <= 0 {
+		return nil, false
+	}
+	if win := FindWinningMove(b, player); win != nil {
+		return []Move{*win}, true
+	}
+	opponent := SwitchPlayer(player)
+	if depth == 1 || FindWinningMove(b, opponent) != nil {
+		return nil, false
+	}
+
+	for _, attack := range threatMoves(b, player) {
+		next := b
+		ApplyMove(&next, attack, player)
+		if FindWinningMove(next, opponent) != nil {
+			continue // el rival gana antes de tener que bloquear
+		}
+
+		candidates := defenseCandidates(next, player)
+		if len(candidates) == 0 {
+			continue // no hay dónde responder: el tablero está casi lleno
+		}
+		// Cada defensa que bloquea debe perder; la primera que resiste descarta
+		// el ataque, sin generar el resto
+		var line []Move
+		forced, blocked := true, false
+		for _, reply := range candidates {
+			after := next
+			ApplyMove(&after, reply, opponent)
+			if FindWinningMove(after, player) != nil {
+				continue // no bloquea todas las amenazas
+			}
+			blocked = true
+			rest, ok := forcedWin(after, player, depth-1)
+			if !ok {
+				forced = false
+				break
+			}
+			if line == nil {
+				line = append([]Move{attack, reply}, rest...)
+			}
+		}
+		if !blocked {
+			// Ningún par de piedras bloquea todas las amenazas: cualquier defensa pierde
+			reply := candidates[0]
+			after := next
+			ApplyMove(&after, reply, opponent)
+			return []Move{attack, reply, *FindWinningMove(after, player)}, true
+		}
+		if forced {
+			return line, true
+		}
+	}
+	return nil, false
+}
+
+// threatMoves genera las jugadas de dos piedras que dejan alguna ventana de
+// WinLength con al menos WinLength-2 piedras de 'player' y sin piedras rivales:
+// pares de huecos de una misma ventana, o dos piedras que crean amenazas por separado
+// Retorna: Movimientos sin repetir, en orden de recorrido del tablero
+func threatMoves(b Board, player rune) []Move {
+	seen := make(map[Move]bool)
+	var moves []Move
+	add := func(p, q Position) {
+		move := normalizePair(Move{p, q})
+		if p != q && !seen[move] {
+			seen[move] = true
+			moves = append(moves, move)
+		}
+	}
+
+	var singles []Position // huecos que crean una amenaza con una sola piedra
+	single := make(map[Position]bool)
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			for _, d := range chainDirections {
+				empties, own, ok := windowAt(b, r, c, d.dr, d.dc, player)
+				if !ok || own+2 < WinLength-2 || own >= WinLength-2 {
+					continue
+				}
+				for i := 0; i < len(empties); i++ {
+					for j := i + 1; j < len(empties); j++ {
+						add(empties[i], empties[j])
+					}
+					if own+1 >= WinLength-2 && !single[empties[i]] {
+						single[empties[i]] = true
+						singles = append(singles, empties[i])
+					}
+				}
+			}
+		}
+	}
+	for i := 0; i < len(singles); i++ {
+		for j := i + 1; j < len(singles); j++ {
+			add(singles[i], singles[j])
+		}
+	}
+	return moves
+}
+
+// defenseCandidates genera las respuestas de dos piedras a considerar contra las
+// amenazas de 'attacker': pares de huecos de sus amenazas y, para cada hueco,
+// la segunda piedra en cualquier casilla desde la que el atacante podría armar
+// su próxima amenaza (huecos de ventanas con WinLength-4 piedras suyas o más)
+// o que le da al defensor una amenaza propia (ventanas con WinLength-3 piedras
+// suyas o más), que obliga al atacante a responder
+func defenseCandidates(b Board, attacker rune) []Move {
+	gaps := FindSplitFours(b, attacker)
+	defender := SwitchPlayer(attacker)
+	seconds := append(windowCells(b, attacker, WinLength-4), windowCells(b, defender, WinLength-3)...)
+
+	seen := make(map[Move]bool)
+	var candidates []Move
+	add := func(p, q Position) {
+		move := normalizePair(Move{p, q})
+		if p != q && !seen[move] {
+			seen[move] = true
+			candidates = append(candidates, move)
+		}
+	}
+	for i := 0; i < len(gaps); i++ {
+		for j := i + 1; j < len(gaps); j++ {
+			add(gaps[i], gaps[j])
+		}
+	}
+	for _, gap := range gaps {
+		for _, q := range seconds {
+			add(gap, q)
+		}
+	}
+	return candidates
+}
+
+// windowCells retorna los huecos de las ventanas de WinLength celdas con al menos
+// 'minOwn' piedras de 'player' y ninguna del rival, sin repetir y en orden de recorrido
+func windowCells(b Board, player rune, minOwn int) []Position {
+	seen := make(map[Position]bool)
+	var cells []Position
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			for _, d := range chainDirections {
+				empties, own, ok := windowAt(b, r, c, d.dr, d.dc, player)
+				if !ok || own < minOwn {
+					continue
+				}
+				for _, p := range empties {
+					if !seen[p] {
+						seen[p] = true
+						cells = append(cells, p)
+					}
+				}
+			}
+		}
+	}
+	return cells
+}
+
+// windowAt examina la ventana de WinLength celdas que empieza en (r,c) en la dirección (dr,dc)
+// Retorna: Sus huecos, cuántas piedras de 'player' tiene, y false si sale
+// del tablero o contiene piedras del rival
+func windowAt(b Board, r, c, dr, dc int, player rune) ([]Position, int, bool) {
+	endR, endC := r+dr*(WinLength-1), c+dc*(WinLength-1)
+	if endR < 0 || endR >= BoardSize || endC < 0 || endC >= BoardSize {
+		return nil, 0, false
+	}
+	var empties []Position
+	own := 0
+	for step := 0; step < WinLength; step++ {
+		nr, nc := r+dr*step, c+dc*step
+		switch b[nr][nc] {
+		case player:
+			own++
+		case '\x00':
+			empties = append(empties, Position{nr, nc})
+		default:
+			return nil, 0, false
+		}
+	}
+	return empties, own, true
+}
@@ -0,0 +1,67 @@
+package board
+
+import (
+	"testing"
+)
+
+// TestFindForcedWinInTwo: tres negras en la fila 9 y tres en la columna 9;
+// la jugada (6,9)+(9,9) deja dos amenazas que ninguna defensa cubre a la vez
+func TestFindForcedWinInTwo(t *testing.T) {
+	var b Board
+	for _, p := range []Position{{9, 5}, {9, 6}, {9, 7}, {10, 9}, {11, 9}, {12, 9}} {
+		b[p.Row][p.Col] = 'B'
+	}
+	for _, p := range []Position{{0, 0}, {0, 1}, {18, 0}, {18, 1}, {18, 18}, {0, 18}, {17, 18}} {
+		b[p.Row][p.Col] = 'W'
+	}
+
+	if _, ok := FindForcedWin(b, 'B', 1); ok {
+		t.Fatal("no hay victoria en un turno, pero FindForcedWin(1) la encontró")
+	}
+	line, ok := FindForcedWin(b, 'B', 2)
+	if !ok || len(line) != 3 {
+		t.Fatalf("FindForcedWin(2) = %v, %v; want ataque, defensa y victoria", line, ok)
+	}
+	player := 'B'
+	for i, move := range line {
+		if err := ApplyMoveChecked(&b, move, player); err != nil {
+			t.Fatalf("jugada %d %v ilegal: %v", i, move, err)
+		}
+		player = SwitchPlayer(player)
+	}
+	if !CheckWin(b, 'B') {
+		t.Errorf("la línea %v no termina en victoria de las negras", line)
+	}
+}
+
+// TestDefenseCandidatesUseSecondStone: con un solo hueco que bloquear, la
+// segunda piedra se prueba donde el atacante arma su próxima amenaza y donde
+// el defensor amenaza por su cuenta, no en una casilla cualquiera
+func TestDefenseCandidatesUseSecondStone(t *testing.T) {
+	var b Board
+	for c := 5; c <= 9; c++ {
+		b[9][c] = 'B' // cinco negras: el único hueco es (9,10)
+	}
+	b[9][4], b[9][11] = 'W', 'W'
+	b[3][3], b[3][4] = 'B', 'B' // pareja negra en desarrollo
+	for c := 3; c <= 5; c++ {
+		b[15][c] = 'W' // tres blancas
+	}
+
+	gap := Position{9, 10}
+	seen := make(map[Move]bool)
+	for _, move := range defenseCandidates(b, 'B') {
+		if move[0] != gap && move[1] != gap {
+			t.Errorf("%v no bloquea el hueco %v", move, gap)
+		}
+		seen[move] = true
+	}
+	for _, second := range []Position{{3, 5}, {15, 6}} {
+		if !seen[normalizePair(Move{gap, second})] {
+			t.Errorf("falta la defensa %v + %v", gap, second)
+		}
+	}
+	if seen[normalizePair(Move{gap, {0, 0}})] {
+		t.Error("la segunda piedra se probó lejos de toda amenaza, en (0,0)")
+	}
+}
@@ -0,0 +1,49 @@
+package board
+
+// InfluenceScore mide el control de 'player' sobre las casillas vacías,
+// independiente de la puntuación de cadenas de EvaluateBoard
+// Parámetros:
+// - b: Tablero actual
+// - player: Jugador cuya influencia se mide
+// Retorna: Suma, por cada casilla vacía y cada ventana de WinLength celdas que
+// la contiene sin piedras rivales (una línea abierta), de las piedras de
+// 'player' en esa ventana: cuentan las piedras cercanas y en líneas vivas
+func InfluenceScore(b Board, player rune) float64 {
+	return DefaultRules.InfluenceScore(b, player)
+}
+
+// InfluenceScore calcula la influencia con ventanas de rules.WinLength celdas
+func (rules Rules) InfluenceScore(b Board, player rune) float64 {
+	opponent := SwitchPlayer(player)
+	score := 0
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			if b[r][c] != '\x00' {
+				continue
+			}
+			for _, d := range chainDirections {
+				// Ventanas que empiezan 0..WinLength-1 celdas antes de (r,c)
+				for back := 0; back < rules.WinLength; back++ {
+					sr, sc := r-d.dr*back, c-d.dc*back
+					er, ec := sr+d.dr*(rules.WinLength-1), sc+d.dc*(rules.WinLength-1)
+					if !inBounds(Position{sr, sc}) || !inBounds(Position{er, ec}) {
+						continue
+					}
+					stones := 0
+					for step := 0; step < rules.WinLength; step++ {
+						cell := b[sr+d.dr*step][sc+d.dc*step]
+						if cell == opponent {
+							stones = 0
+							break
+						}
+						if cell == player {
+							stones++
+						}
+					}
+					score += stones
+				}
+			}
+		}
+	}
+	return float64(score)
+}
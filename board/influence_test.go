@@ -0,0 +1,34 @@
+package board
+
+import (
+	"testing"
+)
+
+// TestInfluenceRewardsOpenLines: las mismas piedras negras controlan más casillas
+// en líneas abiertas que encerradas por blancas, y el peso Influence suma esa
+// diferencia a EvaluateBoard
+func TestInfluenceRewardsOpenLines(t *testing.T) {
+	var open, closed Board
+	for c := 8; c <= 10; c++ {
+		open[9][c] = 'B'
+		closed[9][c] = 'B'
+	}
+	for i, p := range []Position{{9, 7}, {9, 11}, {8, 9}, {10, 9}} {
+		closed[p.Row][p.Col] = 'W'
+		open[2][2+2*i] = 'W' // mismas piedras blancas, lejos
+	}
+
+	if o, c := InfluenceScore(open, 'B'), InfluenceScore(closed, 'B'); o <= c {
+		t.Errorf("InfluenceScore abierto = %.0f, no mayor que encerrado = %.0f", o, c)
+	}
+
+	weights := DefaultWeights
+	weights.Influence = 10
+	rules := DefaultRules.WithWeights(weights)
+	for _, b := range []Board{open, closed} {
+		want := EvaluateBoard(b, 'B') + 10*(InfluenceScore(b, 'B')-InfluenceScore(b, 'W'))
+		if got := rules.EvaluateBoard(b, 'B'); got != want {
+			t.Errorf("EvaluateBoard con Influence 10 = %.0f, want %.0f", got, want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package board
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// boardJSON es la forma serializada de Board para interfaces externas
+type boardJSON struct {
+	Size   int        `json:"size"`
+	Cells  [][]string `json:"cells"`  // filas de ".", "B" o "W"
+	ToMove string     `json:"toMove"` // derivado del conteo de piedras (GetCurrentPlayer)
+}
+
+// MarshalJSON codifica el tablero como {"size":19,"cells":[[".","B",...],...],"toMove":"W"}
+func (b Board) MarshalJSON() ([]byte, error) {
+	out := boardJSON{
+		Size:   BoardSize,
+		Cells:  make([][]string, BoardSize),
+		ToMove: string(GetCurrentPlayer(b)),
+	}
+	for r := 0; r < BoardSize; r++ {
+		out.Cells[r] = make([]string, BoardSize)
+		for c := 0; c < BoardSize; c++ {
+			switch b[r][c] {
+			case 'B', 'W':
+				out.Cells[r][c] = string(b[r][c])
+			default:
+				out.Cells[r][c] = "."
+			}
+		}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodifica el formato de MarshalJSON
+// El campo "toMove" se ignora: siempre se deriva de las piedras
+// Retorna: error si el JSON está mal formado, el tamaño no es BoardSize
+// o alguna celda no es ".", "B" o "W"; en ese caso 'b' no se modifica
+func (b *Board) UnmarshalJSON(data []byte) error {
+	var in boardJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	if in.Size != BoardSize {
+		return fmt.Errorf("tamaño inválido: se esperaba %d, hay %d", BoardSize, in.Size)
+	}
+	if len(in.Cells) != BoardSize {
+		return fmt.Errorf("se esperaban %d filas, hay %d", BoardSize, len(in.Cells))
+	}
+
+	var parsed Board
+	for r, row := range in.Cells {
+		if len(row) != BoardSize {
+			return fmt.Errorf("fila %d: se esperaban %d celdas, hay %d", r, BoardSize, len(row))
+		}
+		for c, cell := range row {
+			switch cell {
+			case ".":
+			case "B", "W":
+				parsed[r][c] = rune(cell[0])
+			default:
+				return fmt.Errorf("celda inválida %q en la posición (%d,%d)", cell, r, c)
+			}
+		}
+	}
+	*b = parsed
+	return nil
+}
@@ -0,0 +1,67 @@
+package board
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// TestBoardJSONRoundTrip: codificar y decodificar conserva el tablero, y
+// volver a codificar da exactamente el mismo JSON
+func TestBoardJSONRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(48))
+	for i := 0; i < 50; i++ {
+		b := randomBoard(rng, rng.Float64())
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded Board
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("posición %d: %v", i, err)
+		}
+		if decoded != b {
+			t.Fatalf("posición %d: el tablero cambió al decodificarlo", i)
+		}
+		again, _ := json.Marshal(decoded)
+		if string(again) != string(data) {
+			t.Fatalf("posición %d: el JSON no es estable", i)
+		}
+	}
+
+	var b Board
+	b[9][9] = 'B'
+	data, _ := json.Marshal(b)
+	if !strings.Contains(string(data), `"size":19`) || !strings.Contains(string(data), `"toMove":"W"`) {
+		t.Errorf("faltan size o toMove: %s", data)
+	}
+}
+
+// TestBoardJSONRejectsMalformed: el JSON inválido da error sin tocar el tablero
+func TestBoardJSONRejectsMalformed(t *testing.T) {
+	var empty Board
+	valid, _ := json.Marshal(empty)
+	badCell := strings.Replace(string(valid), `"."`, `"X"`, 1)
+	shortRow := strings.Replace(string(valid), `".",`, ``, 1)
+
+	for _, data := range []string{
+		`{`,
+		`[]`,
+		`{"size":13,"cells":[]}`,
+		`{"size":19,"cells":[[]]}`,
+		`{"size":19,"cells":"B"}`,
+		badCell,
+		shortRow,
+	} {
+		b := empty
+		b[0][0] = 'W'
+		before := b
+		if err := json.Unmarshal([]byte(data), &b); err == nil {
+			t.Errorf("%.40q: sin error", data)
+		}
+		if b != before {
+			t.Errorf("%.40q: el tablero cambió pese al error", data)
+		}
+	}
+}
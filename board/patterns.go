@@ -0,0 +1,53 @@
+package board
+
+// PatternScore puntúa las formaciones de 'player': cadenas continuas
+// (WeightedChainScore) más los patrones con un hueco como "X.XX" o "XX.XX"
+// Parámetros:
+// - b: Tablero actual
+// - player: Jugador a evaluar
+// Retorna: Puntaje total (mayor = mejores formaciones)
+func PatternScore(b Board, player rune) int {
+	return DefaultRules.PatternScore(b, player)
+}
+
+// PatternScore puntúa las formaciones de 'player' según rules.WinLength
+func (rules Rules) PatternScore(b Board, player rune) int {
+	runs := ChainRuns(b)
+	score := 0
+	for _, run := range runs {
+		if run.Player == player {
+			score += rules.WeightedChainScore(run.Length, run.BlockedStart, run.BlockedEnd)
+		}
+	}
+	return score + rules.gapPatternScore(b, runs, player)
+}
+
+// gapPatternScore puntúa los pares de cadenas de 'player' separados por una sola
+// casilla vacía en la misma dirección. Un patrón de n piedras vale como una cadena
+// continua de n-1 con los mismos extremos (p. ej. "XX.XX" cerca de un tres abierto)
+func (rules Rules) gapPatternScore(b Board, runs []ChainRun, player rune) int {
+	inRange := func(r, c int) bool {
+		return r >= 0 && r < BoardSize && c >= 0 && c < BoardSize
+	}
+
+	score := 0
+	for _, run := range runs {
+		if run.Player != player {
+			continue
+		}
+		gapR, gapC := run.Start.Row+run.DR*run.Length, run.Start.Col+run.DC*run.Length
+		nextR, nextC := gapR+run.DR, gapC+run.DC
+		if !inRange(nextR, nextC) || b[gapR][gapC] != '\x00' || b[nextR][nextC] != player {
+			continue
+		}
+
+		second := 0
+		for inRange(nextR, nextC) && b[nextR][nextC] == player {
+			second++
+			nextR, nextC = nextR+run.DR, nextC+run.DC
+		}
+		blockedEnd := !inRange(nextR, nextC) || b[nextR][nextC] != '\x00'
+		score += rules.WeightedChainScore(run.Length+second-1, run.BlockedStart, blockedEnd)
+	}
+	return score
+}
@@ -0,0 +1,47 @@
+package board
+
+import (
+	"testing"
+)
+
+// rowBoard coloca las piedras de 'pattern' ('X' = negra) en la fila 9 desde la columna 1
+func rowBoard(pattern string) Board {
+	var b Board
+	for i, ch := range pattern {
+		if ch == 'X' {
+			b[9][1+i] = 'B'
+		}
+	}
+	return b
+}
+
+// TestGapPatternsOutscoreIsolatedStones: con las mismas piedras, cada patrón
+// con un hueco puntúa más que las piedras sueltas, y menos que la cadena continua
+// de las mismas piedras
+func TestGapPatternsOutscoreIsolatedStones(t *testing.T) {
+	for _, tc := range []struct{ gapped, isolated, solid string }{
+		{"X.X", "X...X", "XX"},
+		{"X.XX", "X...X...X", "XXX"},
+		{"XX.XX", "X...X...X...X", "XXXX"},
+		{"X.XXX", "X...X...X...X", "XXXX"},
+		{"XX.XXX", "X...X...X...X...X", "XXXXX"},
+	} {
+		gapped := PatternScore(rowBoard(tc.gapped), 'B')
+		isolated := PatternScore(rowBoard(tc.isolated), 'B')
+		solid := PatternScore(rowBoard(tc.solid), 'B')
+		if gapped <= isolated {
+			t.Errorf("%q puntúa %d, no más que las piedras sueltas %q (%d)", tc.gapped, gapped, tc.isolated, isolated)
+		}
+		if gapped >= solid {
+			t.Errorf("%q puntúa %d, no menos que la cadena %q (%d)", tc.gapped, gapped, tc.solid, solid)
+		}
+	}
+
+	// Un cuatro con hueco vale cerca de un tres abierto
+	if got, three := PatternScore(rowBoard("XX.XX"), 'B'), DefaultWeights.ThreeOpen; got < three {
+		t.Errorf("XX.XX puntúa %d, menos que un tres abierto (%d)", got, three)
+	}
+	if PatternScore(rowBoard("XX.XX"), 'W') != 0 {
+		t.Error("las piedras negras puntúan para las blancas")
+	}
+}
@@ -0,0 +1,148 @@
+package board
+
+// Rules agrupa los parámetros de la variante que se juega sobre el tablero
+// Permite usar el mismo motor para Connect5 (estilo Gomoku) o Connect4
+type Rules struct {
+	WinLength int         // Piedras en línea necesarias para ganar
+	Weights   EvalWeights // Pesos de la evaluación; el valor cero usa DefaultWeights
+
+	// ExactWinLength exige exactamente WinLength piedras en línea: una sobrelínea
+	// (p.ej. siete en Connect6) no gana. Lo respetan CheckWin, CheckWinAt y
+	// FindWinningMove de estas reglas
+	ExactWinLength bool
+
+	// Topes de GenerateSmartMoves: menos movimientos => búsqueda más rápida pero
+	// más estrecha. 0 usa DefaultMaxPairs y DefaultMaxMoves
+	MaxPairs int // pares de posiciones prioritarias que genera baseSmartMoves
+	MaxMoves int // movimientos totales que retorna GenerateSmartMoves
+
+	// Radius es la distancia a las piedras de las posiciones candidatas de
+	// GenerateSmartMoves (0 usa DefaultRadius); con AdaptiveRadius se amplía
+	// cuando hay pocas candidatas (ver GetPriorityPositionsAdaptive)
+	Radius         int
+	AdaptiveRadius bool
+}
+
+// Topes por defecto de GenerateSmartMoves (ver Rules.MaxPairs y Rules.MaxMoves)
+const (
+	DefaultMaxPairs = 100
+	DefaultMaxMoves = 150
+)
+
+// DefaultRules son las reglas estándar de Connect6
+// Las funciones de paquete (CheckWin, GenerateSmartMoves, EvaluateBoard...) las usan:
+// cambiar sus topes cambia la amplitud de generación de todo el motor
+var DefaultRules = Rules{WinLength: WinLength, Weights: DefaultWeights, MaxPairs: DefaultMaxPairs, MaxMoves: DefaultMaxMoves}
+
+// EvalWeights son los puntajes de WeightedChainScore y EvaluateBoard
+// Los nombres indican el caso en Connect6; con otro WinLength se aplican a
+// las cadenas a las que les faltan 1 (Five), 2 (Four), 3 (Three) o 4 (Two) piedras
+// Open = ambos extremos libres, Half = uno libre, Closed/Blocked = ninguno
+type EvalWeights struct {
+	Win int // cadena de WinLength o más
+
+	FiveOpen, FiveHalf, FiveClosed    int
+	FourOpen, FourHalf, FourClosed    int
+	ThreeOpen, ThreeHalf, ThreeClosed int
+	TwoOpen, TwoBlocked               int // TwoBlocked: uno o ambos extremos bloqueados
+
+	Single int // piedra suelta o cadena más lejos de la victoria
+
+	DoubleFour int // bonificación de EvaluateBoard por dos o más cuatros abiertos
+
+	// Bonificaciones de EvaluateBoard si hay alguna casilla con esa doble amenaza
+	// (ver DoubleThreats)
+	FourFour, FourThree, ThreeThree int
+
+	// Influence multiplica la diferencia de InfluenceScore (propia - rival) que
+	// EvaluateBoard suma a las cadenas; 0 (por defecto) no la calcula
+	Influence float64
+}
+
+// DefaultWeights son los pesos históricos del motor
+var DefaultWeights = EvalWeights{
+	Win:         999999,
+	FiveOpen:    100000,
+	FiveHalf:    50000,
+	FiveClosed:  20000,
+	FourOpen:    30000,
+	FourHalf:    15000,
+	FourClosed:  5000,
+	ThreeOpen:   7000,
+	ThreeHalf:   3000,
+	ThreeClosed: 1000,
+	TwoOpen:     1500,
+	TwoBlocked:  500,
+	Single:      50,
+	DoubleFour:  200000,
+	FourFour:    150000,
+	FourThree:   80000,
+	ThreeThree:  20000,
+}
+
+// WithWeights retorna una copia de las reglas que evalúa con los pesos 'w'
+func (rules Rules) WithWeights(w EvalWeights) Rules {
+	rules.Weights = w
+	return rules
+}
+
+// WithMoveCaps retorna una copia de las reglas con los topes de GenerateSmartMoves dados
+func (rules Rules) WithMoveCaps(maxPairs, maxMoves int) Rules {
+	rules.MaxPairs = maxPairs
+	rules.MaxMoves = maxMoves
+	return rules
+}
+
+// radius retorna el radio de las posiciones candidatas (DefaultRadius si vale 0)
+func (rules Rules) radius() int {
+	if rules.Radius <= 0 {
+		return DefaultRadius
+	}
+	return rules.Radius
+}
+
+// priorityPositions retorna las posiciones candidatas según Radius y AdaptiveRadius
+// 'empties' puede ser nil (ver priorityPositionsIn)
+func (rules Rules) priorityPositions(b Board, empties *EmptySet) []Position {
+	if rules.AdaptiveRadius {
+		return adaptivePriorityPositions(b, empties, rules.radius())
+	}
+	return priorityPositionsIn(b, empties, rules.radius())
+}
+
+// runLimit es hasta dónde se cuenta una cadena: WinLength basta para ganar
+// salvo con ExactWinLength, que necesita la longitud completa
+func (rules Rules) runLimit() int {
+	if rules.ExactWinLength {
+		return BoardSize
+	}
+	return rules.WinLength
+}
+
+// completes indica si una cadena de 'length' piedras gana con estas reglas
+func (rules Rules) completes(length int) bool {
+	if rules.ExactWinLength {
+		return length == rules.WinLength
+	}
+	return length >= rules.WinLength
+}
+
+// moveCaps retorna los topes a usar, con los valores por defecto donde valen 0
+func (rules Rules) moveCaps() (maxPairs, maxMoves int) {
+	maxPairs, maxMoves = rules.MaxPairs, rules.MaxMoves
+	if maxPairs <= 0 {
+		maxPairs = DefaultMaxPairs
+	}
+	if maxMoves <= 0 {
+		maxMoves = DefaultMaxMoves
+	}
+	return maxPairs, maxMoves
+}
+
+// weights retorna los pesos a usar: rules.Weights, o DefaultWeights si es el valor cero
+func (rules Rules) weights() EvalWeights {
+	if rules.Weights == (EvalWeights{}) {
+		return DefaultWeights
+	}
+	return rules.Weights
+}
@@ -0,0 +1,95 @@
+package board
+
+import (
+	"testing"
+)
+
+// TestRulesWinLength: la misma fila gana o no según rules.WinLength, en
+// CheckWin, CheckWinAt, IsTerminal y FindWinningMove
+func TestRulesWinLength(t *testing.T) {
+	var b Board
+	for c := 3; c < 7; c++ {
+		b[4][c] = 'B' // cuatro negras de (4,3) a (4,6)
+	}
+	b[10][10] = 'W'
+
+	for _, n := range []int{4, 5, 6} {
+		rules := Rules{WinLength: n}
+		want := n == 4
+		if got := rules.CheckWin(b, 'B'); got != want {
+			t.Errorf("WinLength %d: CheckWin = %v, want %v", n, got, want)
+		}
+		if got := rules.CheckWinAt(b, Position{Row: 4, Col: 5}, 'B'); got != want {
+			t.Errorf("WinLength %d: CheckWinAt = %v, want %v", n, got, want)
+		}
+		if over, winner := rules.IsTerminal(b); over != want || (want && winner != 'B') {
+			t.Errorf("WinLength %d: IsTerminal = %v, %q", n, over, winner)
+		}
+		if want {
+			continue
+		}
+		// Con 5 y 6 faltan una o dos piedras: un turno basta para completar la línea
+		move := rules.FindWinningMove(b, 'B')
+		if move == nil {
+			t.Fatalf("WinLength %d: no se encontró la victoria inmediata", n)
+		}
+		after := b
+		ApplyMove(&after, *move, 'B')
+		if !rules.CheckWin(after, 'B') {
+			t.Errorf("WinLength %d: %v no gana", n, *move)
+		}
+	}
+}
+
+// TestCustomWeightsChangeEvaluation: WithWeights cambia lo que valen las
+// cadenas en WeightedChainScore y EvaluateBoard; el valor cero usa DefaultWeights
+func TestCustomWeightsChangeEvaluation(t *testing.T) {
+	var b Board
+	for c := 5; c < 8; c++ {
+		b[9][c] = 'B' // tres abierto
+	}
+	b[0][0] = 'W'
+
+	if got := (Rules{WinLength: WinLength}).WeightedChainScore(3, false, false); got != DefaultWeights.ThreeOpen {
+		t.Errorf("pesos cero: tres abierto = %d, want %d", got, DefaultWeights.ThreeOpen)
+	}
+
+	custom := DefaultWeights
+	custom.ThreeOpen *= 10
+	rules := DefaultRules.WithWeights(custom)
+	if got := rules.WeightedChainScore(3, false, false); got != custom.ThreeOpen {
+		t.Errorf("tres abierto = %d, want %d", got, custom.ThreeOpen)
+	}
+	if got := rules.WeightedChainScore(3, true, false); got != DefaultWeights.ThreeHalf {
+		t.Errorf("tres semiabierto = %d, want %d (sin cambiar)", got, DefaultWeights.ThreeHalf)
+	}
+	if base, tuned := EvaluateBoard(b, 'B'), rules.EvaluateBoard(b, 'B'); tuned <= base {
+		t.Errorf("EvaluateBoard con el tres abierto x10 = %.0f, no mayor que %.0f", tuned, base)
+	}
+	if DefaultRules.Weights != DefaultWeights {
+		t.Error("WithWeights modificó DefaultRules")
+	}
+}
+
+// TestMoveCapsLimitGeneration: bajar MaxMoves recorta los movimientos generados
+// a ese número y bajar MaxPairs también los reduce
+func TestMoveCapsLimitGeneration(t *testing.T) {
+	var b Board
+	for i, p := range []Position{{9, 9}, {9, 10}, {10, 9}, {8, 11}, {11, 11}} {
+		b[p.Row][p.Col] = rune("BWWBB"[i])
+	}
+
+	full := DefaultRules.GenerateSmartMoves(b)
+	if len(full) <= 40 || len(full) > DefaultMaxMoves {
+		t.Fatalf("con los topes por defecto se generan %d movimientos", len(full))
+	}
+	if got := DefaultRules.WithMoveCaps(DefaultMaxPairs, 40).GenerateSmartMoves(b); len(got) != 40 {
+		t.Errorf("MaxMoves 40: %d movimientos", len(got))
+	}
+	if got := DefaultRules.WithMoveCaps(10, DefaultMaxMoves).GenerateSmartMoves(b); len(got) >= len(full) {
+		t.Errorf("MaxPairs 10: %d movimientos, no menos que %d", len(got), len(full))
+	}
+	if got := DefaultRules.WithMoveCaps(0, 0).GenerateSmartMoves(b); len(got) != len(full) {
+		t.Errorf("topes 0: %d movimientos, want los %d por defecto", len(got), len(full))
+	}
+}
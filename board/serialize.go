@@ -0,0 +1,77 @@
+package board
+
+import (
+	"fmt"
+)
+
+// Serialize convierte el tablero en una cadena compacta de 361 caracteres
+// Parámetros:
+// - b: Tablero a serializar
+// Retorna: Cadena fila por fila con '.' (vacía), 'B' (negras) y 'W' (blancas)
+func Serialize(b Board) string {
+	return BoardHash(b)
+}
+
+// Parse reconstruye un tablero a partir de una cadena generada por Serialize
+// Parámetros:
+// - s: Cadena de BoardSize*BoardSize caracteres ('.', 'B' o 'W')
+// Retorna: Tablero reconstruido, o error si la longitud o algún carácter no es válido
+func Parse(s string) (Board, error) {
+	var b Board
+	cells := []rune(s)
+	if len(cells) != BoardSize*BoardSize {
+		return b, fmt.Errorf("longitud inválida: se esperaban %d caracteres, hay %d", BoardSize*BoardSize, len(cells))
+	}
+
+	for i, cell := range cells {
+		r, c := i/BoardSize, i%BoardSize
+		switch cell {
+		case '.':
+			b[r][c] = '\x00'
+		case 'B', 'W':
+			b[r][c] = cell
+		default:
+			return b, fmt.Errorf("carácter inválido %q en la posición (%d,%d)", cell, r, c)
+		}
+	}
+	return b, nil
+}
+
+// ValidatePosition comprueba que el número de piedras de cada color se pueda
+// alcanzar jugando con las reglas de Connect6: negras abren con una piedra y
+// después cada turno coloca dos, así que tras cada turno completo las negras
+// tienen un número impar de piedras y las blancas una más o una menos
+// Parámetros:
+// - b: Tablero a validar
+// Retorna: nil si el tablero está vacío o los conteos son posibles; si no,
+// un error con los conteos encontrados
+func ValidatePosition(b Board) error {
+	black, white := 0, 0
+	for _, row := range b {
+		for _, cell := range row {
+			switch cell {
+			case 'B':
+				black++
+			case 'W':
+				white++
+			}
+		}
+	}
+	if black == 0 && white == 0 {
+		return nil
+	}
+	if black%2 == 1 && (white == black-1 || white == black+1) {
+		return nil
+	}
+	return fmt.Errorf("conteo de piedras imposible: %d negras y %d blancas (las negras deben ser impares y las blancas una más o una menos)", black, white)
+}
+
+// ParsePosition es Parse seguido de ValidatePosition: para cargar posiciones
+// de partida (archivos, banderas) y rechazar las que no salen de una partida
+func ParsePosition(s string) (Board, error) {
+	b, err := Parse(s)
+	if err != nil {
+		return b, err
+	}
+	return b, ValidatePosition(b)
+}
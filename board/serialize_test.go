@@ -0,0 +1,94 @@
+package board
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestValidatePositionStoneCounts: los conteos de una secuencia de turnos de una
+// y luego dos piedras se aceptan; el resto se rechaza, también en ParsePosition
+func TestValidatePositionStoneCounts(t *testing.T) {
+	// withStones pone 'black' negras y 'white' blancas sin formar líneas
+	withStones := func(black, white int) Board {
+		var b Board
+		for i := 0; i < black; i++ {
+			b[2*(i/9)][2*(i%9)] = 'B'
+		}
+		for i := 0; i < white; i++ {
+			b[2*(i/9)+1][2*(i%9)+1] = 'W'
+		}
+		return b
+	}
+
+	for _, counts := range [][2]int{{0, 0}, {1, 0}, {1, 2}, {3, 2}, {3, 4}, {5, 4}, {9, 10}} {
+		b := withStones(counts[0], counts[1])
+		if err := ValidatePosition(b); err != nil {
+			t.Errorf("%d negras y %d blancas: %v", counts[0], counts[1], err)
+		}
+		if _, err := ParsePosition(Serialize(b)); err != nil {
+			t.Errorf("ParsePosition con %d negras y %d blancas: %v", counts[0], counts[1], err)
+		}
+	}
+	for _, counts := range [][2]int{{0, 1}, {2, 1}, {1, 1}, {1, 3}, {3, 0}, {7, 2}, {4, 4}} {
+		b := withStones(counts[0], counts[1])
+		if ValidatePosition(b) == nil {
+			t.Errorf("%d negras y %d blancas se aceptaron", counts[0], counts[1])
+		}
+		if _, err := ParsePosition(Serialize(b)); err == nil {
+			t.Errorf("ParsePosition aceptó %d negras y %d blancas", counts[0], counts[1])
+		}
+	}
+}
+
+// TestSerializeRoundTrip: Parse(Serialize(b)) == b en el tablero vacío, en
+// una posición de partida y en el tablero lleno
+func TestSerializeRoundTrip(t *testing.T) {
+	var empty Board
+
+	var game Board
+	for i, p := range []Position{{9, 9}, {9, 10}, {10, 10}, {8, 8}, {0, 18}, {18, 0}, {10, 8}} {
+		game[p.Row][p.Col] = rune("BWWBBWW"[i])
+	}
+
+	var full Board
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			full[r][c] = rune("BW"[(r+c/2)%2])
+		}
+	}
+
+	for name, b := range map[string]Board{"vacío": empty, "partida": game, "lleno": full} {
+		s := Serialize(b)
+		if len(s) != BoardSize*BoardSize {
+			t.Errorf("%s: Serialize tiene %d caracteres", name, len(s))
+		}
+		got, err := Parse(s)
+		if err != nil {
+			t.Errorf("%s: Parse: %v", name, err)
+			continue
+		}
+		if got != b {
+			t.Errorf("%s: Parse(Serialize(b)) != b:\n%s", name, Serialize(got))
+		}
+	}
+	if s := Serialize(game); s[9*BoardSize+9] != 'B' || s[9*BoardSize+10] != 'W' || s[0] != '.' {
+		t.Errorf("Serialize no es fila por fila con '.', 'B' y 'W': %q...", s[:BoardSize])
+	}
+}
+
+// TestParseRejectsBadInput: longitudes y caracteres inválidos dan error
+func TestParseRejectsBadInput(t *testing.T) {
+	valid := strings.Repeat(".", BoardSize*BoardSize)
+	for name, s := range map[string]string{
+		"vacía":          "",
+		"corta":          valid[1:],
+		"larga":          valid + ".",
+		"minúscula":      "b" + valid[1:],
+		"carácter extra": valid[:100] + "X" + valid[101:],
+		"multibyte":      "ñ" + valid[1:],
+	} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("%s: Parse aceptó la cadena", name)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package board
+
+// Rotate90 gira el tablero 90° en sentido horario
+// Parámetros:
+// - b: Tablero original
+// Retorna: Tablero girado; la celda (fila, col) pasa a (col, BoardSize-1-fila)
+func Rotate90(b Board) Board {
+	return transformBoard(b, 1)
+}
+
+// Reflect refleja el tablero horizontalmente (espejo izquierda-derecha)
+// Parámetros:
+// - b: Tablero original
+// Retorna: Tablero reflejado; la celda (fila, col) pasa a (fila, BoardSize-1-col)
+func Reflect(b Board) Board {
+	return transformBoard(b, 4)
+}
+
+// Symmetries retorna las 8 transformaciones diédricas del tablero
+// Parámetros:
+// - b: Tablero original
+// Retorna: Arreglo indexado por k (ver TransformPosition); Symmetries(b)[0] == b
+func Symmetries(b Board) [8]Board {
+	var out [8]Board
+	for k := range out {
+		out[k] = transformBoard(b, k)
+	}
+	return out
+}
+
+// TransformPosition aplica a 'p' la misma simetría 'k' (0-7) que Symmetries(b)[k]
+// k & 4 refleja primero las columnas; luego se gira 90° en sentido horario k % 4 veces
+// NoPosition no cambia, de modo que los movimientos de una piedra se transforman igual
+func TransformPosition(p Position, k int) Position {
+	if p == NoPosition {
+		return p
+	}
+	n := BoardSize - 1
+	if k&4 != 0 {
+		p.Col = n - p.Col
+	}
+	for i := 0; i < k%4; i++ {
+		p = Position{Row: p.Col, Col: n - p.Row}
+	}
+	return p
+}
+
+// transformBoard aplica la simetría 'k' (0-7) a todo el tablero
+func transformBoard(b Board, k int) Board {
+	var out Board
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			t := TransformPosition(Position{r, c}, k)
+			out[t.Row][t.Col] = b[r][c]
+		}
+	}
+	return out
+}
+
+// isSymmetric indica si el tablero queda igual bajo la simetría 'k'
+// Compara celda a celda y se detiene en la primera diferencia
+func isSymmetric(b Board, k int) bool {
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			t := TransformPosition(Position{r, c}, k)
+			if b[t.Row][t.Col] != b[r][c] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CanonicalMoves elimina los movimientos equivalentes bajo las simetrías
+// que dejan 'b' sin cambios (de las 8 rotaciones/reflexiones del tablero)
+// Parámetros:
+// - b: Tablero actual
+// - moves: Movimientos candidatos
+// Retorna: Un representante por clase de equivalencia, en el orden original
+func CanonicalMoves(b Board, moves []Move) []Move {
+	var syms []int
+	for k := 1; k < 8; k++ {
+		if isSymmetric(b, k) {
+			syms = append(syms, k)
+		}
+	}
+	if len(syms) == 0 {
+		return moves
+	}
+
+	seen := make(map[Move]bool, len(moves))
+	unique := moves[:0:0]
+	for _, move := range moves {
+		if seen[normalizePair(move)] {
+			continue
+		}
+		unique = append(unique, move)
+		seen[normalizePair(move)] = true
+		for _, k := range syms {
+			t := Move{TransformPosition(move[0], k), TransformPosition(move[1], k)}
+			seen[normalizePair(t)] = true
+		}
+	}
+	return unique
+}
+
+// normalizePair ordena las dos piedras de un movimiento para que (a,b) y (b,a) coincidan
+func normalizePair(m Move) Move {
+	if m[1] == NoPosition {
+		return m
+	}
+	if m[1].Row < m[0].Row || (m[1].Row == m[0].Row && m[1].Col < m[0].Col) {
+		m[0], m[1] = m[1], m[0]
+	}
+	return m
+}
@@ -0,0 +1,79 @@
+package board
+
+import (
+	"testing"
+)
+
+// TestCanonicalMovesCollapsesCenter: en el tablero vacío las 25 aperturas del
+// 5x5 central quedan en 6 clases (centro, ejes a 1 y 2, diagonales a 1 y 2, y
+// las casillas (1,2))
+func TestCanonicalMovesCollapsesCenter(t *testing.T) {
+	var b Board
+	center := BoardSize / 2
+	var moves []Move
+	for r := center - 2; r <= center+2; r++ {
+		for c := center - 2; c <= center+2; c++ {
+			moves = append(moves, Move{{r, c}, NoPosition})
+		}
+	}
+	if got := CanonicalMoves(b, moves); len(got) != 6 {
+		t.Errorf("CanonicalMoves dejó %d aperturas, want 6: %v", len(got), got)
+	}
+}
+
+// TestGenerateSmartMovesCanonicalOnlyEarly: con pocas piedras la generación ya
+// sale sin equivalentes; pasado symmetryMaxStones no se buscan simetrías
+func TestGenerateSmartMovesCanonicalOnlyEarly(t *testing.T) {
+	c := BoardSize / 2
+	// Posición simétrica bajo las 8 transformaciones: centro y cruz
+	var early Board
+	early[c][c] = 'B'
+	early[c-1][c], early[c+1][c] = 'W', 'W'
+	early[c][c-1], early[c][c+1] = 'B', 'B'
+	moves := GenerateSmartMoves(early)
+	if len(CanonicalMoves(early, moves)) != len(moves) {
+		t.Errorf("con %d piedras GenerateSmartMoves dejó movimientos equivalentes", StoneCount(early))
+	}
+
+	// La misma idea con más de symmetryMaxStones piedras: esquinas del 5x5 central
+	late := early
+	late[c-2][c-2], late[c-2][c+2], late[c+2][c-2], late[c+2][c+2] = 'W', 'W', 'W', 'W'
+	if StoneCount(late) <= symmetryMaxStones {
+		t.Fatalf("la posición tardía tiene solo %d piedras", StoneCount(late))
+	}
+	moves = GenerateSmartMoves(late)
+	if len(CanonicalMoves(late, moves)) == len(moves) {
+		t.Error("con muchas piedras GenerateSmartMoves siguió descartando simetrías")
+	}
+}
+
+// TestRotate90FourTimesIsIdentity: cuatro giros de 90° devuelven el tablero
+// original, y un giro lleva cada piedra de (fila, col) a (col, BoardSize-1-fila)
+func TestRotate90FourTimesIsIdentity(t *testing.T) {
+	var b Board
+	stones := map[Position]rune{{0, 0}: 'B', {0, 5}: 'W', {3, 17}: 'B', {9, 9}: 'W', {18, 2}: 'B', {12, 18}: 'W'}
+	for p, c := range stones {
+		b[p.Row][p.Col] = c
+	}
+
+	if got := Rotate90(Rotate90(Rotate90(Rotate90(b)))); got != b {
+		t.Errorf("cuatro giros cambiaron el tablero:\n%s\n%s", Serialize(got), Serialize(b))
+	}
+
+	once := Rotate90(b)
+	if once == b {
+		t.Fatal("un giro dejó el tablero igual")
+	}
+	n := BoardSize - 1
+	for p, c := range stones {
+		if got := once[p.Col][n-p.Row]; got != c {
+			t.Errorf("%v: tras un giro (%d,%d) tiene %q, want %q", p, p.Col, n-p.Row, got, c)
+		}
+		if got := TransformPosition(p, 1); got != (Position{Row: p.Col, Col: n - p.Row}) {
+			t.Errorf("TransformPosition(%v, 1) = %v", p, got)
+		}
+	}
+	if StoneCount(once) != len(stones) {
+		t.Errorf("el giro tiene %d piedras, want %d", StoneCount(once), len(stones))
+	}
+}
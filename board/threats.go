@@ -0,0 +1,252 @@
+package board
+
+import (
+	"sort"
+)
+
+// criticalChainLen es el umbral histórico de FindCriticalBlocks
+const criticalChainLen = 4
+
+// FindCriticalBlocks retorna las casillas vacías donde 'opponent' formaría
+// una cadena de al menos 4 piedras; equivale a FindCriticalBlocksN(b, opponent, 4)
+func FindCriticalBlocks(b Board, opponent rune) []Position {
+	return FindCriticalBlocksN(b, opponent, criticalChainLen)
+}
+
+// FindCriticalBlocksN retorna las casillas vacías que conviene bloquear
+// Parámetros:
+// - b: Tablero actual
+// - opponent: Jugador cuyas amenazas se buscan
+// - minLen: Longitud mínima de la cadena que formaría 'opponent' al jugar en la casilla
+// Retorna: Posiciones en orden de filas; una casilla es crítica si, con una piedra
+// de 'opponent' en ella, alguna línea que la atraviesa suma 'minLen' piedras seguidas,
+// o si es un hueco de un cuatro partido (ver FindSplitFours)
+func FindCriticalBlocksN(b Board, opponent rune, minLen int) []Position {
+	var critical [BoardSize][BoardSize]bool
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			if b[r][c] != '\x00' {
+				continue
+			}
+			for _, d := range chainDirections {
+				length := 1 + countDirection(&b, r, c, d.dr, d.dc, opponent) +
+					countDirection(&b, r, c, -d.dr, -d.dc, opponent)
+				if length >= minLen {
+					critical[r][c] = true
+					break
+				}
+			}
+		}
+	}
+	for _, p := range FindSplitFours(b, opponent) {
+		critical[p.Row][p.Col] = true
+	}
+
+	var blocks []Position
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			if critical[r][c] {
+				blocks = append(blocks, Position{r, c})
+			}
+		}
+	}
+	return blocks
+}
+
+// FindSplitFours retorna los huecos de las ventanas de WinLength celdas que
+// 'opponent' completaría con sus dos piedras del turno: ventanas sin piedras
+// ajenas y con a lo sumo 2 huecos (p. ej. "XX.XX.X" o "XXXX..")
+// Aunque ninguna cadena llegue a 4, el rival gana si no se ocupa uno de ellos
+// Parámetros:
+// - b: Tablero actual
+// - opponent: Jugador cuyas amenazas se buscan
+// Retorna: Posiciones sin repetir, en el orden en que se encuentran
+func FindSplitFours(b Board, opponent rune) []Position {
+	seen := make(map[Position]bool)
+	var gaps []Position
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			for _, d := range chainDirections {
+				endR, endC := r+d.dr*(WinLength-1), c+d.dc*(WinLength-1)
+				if endR < 0 || endR >= BoardSize || endC < 0 || endC >= BoardSize {
+					continue
+				}
+
+				var empties []Position
+				blocked := false
+				for step := 0; step < WinLength && !blocked; step++ {
+					nr, nc := r+d.dr*step, c+d.dc*step
+					switch b[nr][nc] {
+					case opponent:
+					case '\x00':
+						empties = append(empties, Position{nr, nc})
+					default:
+						blocked = true
+					}
+				}
+				if blocked || len(empties) == 0 || len(empties) > 2 {
+					continue
+				}
+				for _, p := range empties {
+					if !seen[p] {
+						seen[p] = true
+						gaps = append(gaps, p)
+					}
+				}
+			}
+		}
+	}
+	return gaps
+}
+
+// countDirection cuenta las piedras seguidas de 'player' desde (r,c), sin incluirla,
+// avanzando en la dirección (dr,dc)
+func countDirection(b *Board, r, c, dr, dc int, player rune) int {
+	count := 0
+	for {
+		r, c = r+dr, c+dc
+		if r < 0 || r >= BoardSize || c < 0 || c >= BoardSize || b[r][c] != player {
+			return count
+		}
+		count++
+	}
+}
+
+// ThreatMap calcula, para cada casilla vacía, la cadena más larga que 'player'
+// formaría al jugar allí (la piedra nueva más sus vecinas consecutivas, en la
+// mejor de las 4 direcciones); pensado para dibujarse como mapa de calor
+// Parámetros:
+// - b: Tablero actual
+// - player: Jugador cuyas amenazas se miden
+// Retorna: Matriz con la longitud por casilla; las casillas ocupadas valen 0
+func ThreatMap(b Board, player rune) [BoardSize][BoardSize]int {
+	var threats [BoardSize][BoardSize]int
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			if b[r][c] != '\x00' {
+				continue
+			}
+			for _, d := range chainDirections {
+				length := 1 + countDirection(&b, r, c, d.dr, d.dc, player) + countDirection(&b, r, c, -d.dr, -d.dc, player)
+				if length > threats[r][c] {
+					threats[r][c] = length
+				}
+			}
+		}
+	}
+	return threats
+}
+
+// FindOpenThrees retorna las casillas que bloquean los tres abiertos de 'player':
+// los extremos libres de sus cadenas de WinLength-3 piedras sin extremos bloqueados
+// Parámetros:
+// - b: Tablero actual
+// - player: Jugador cuyos tres abiertos se buscan
+// Retorna: Posiciones sin repetir; primero las que cortan más tres abiertos a la vez
+// (las más peligrosas) y, ante empate, en orden de recorrido del tablero
+func FindOpenThrees(b Board, player rune) []Position {
+	return DefaultRules.FindOpenThrees(b, player)
+}
+
+// FindOpenThrees busca los tres abiertos de 'player' con el WinLength de estas
+// reglas (cadenas de rules.WinLength-3 piedras)
+func (rules Rules) FindOpenThrees(b Board, player rune) []Position {
+	count := make(map[Position]int)
+	var order []Position
+	for _, run := range ChainRuns(b) {
+		if run.Player != player || run.Length != rules.WinLength-3 || run.BlockedStart || run.BlockedEnd {
+			continue
+		}
+		before := Position{run.Start.Row - run.DR, run.Start.Col - run.DC}
+		after := Position{run.Start.Row + run.DR*run.Length, run.Start.Col + run.DC*run.Length}
+		for _, p := range []Position{before, after} {
+			if count[p] == 0 {
+				order = append(order, p)
+			}
+			count[p]++
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return count[order[i]] > count[order[j]]
+	})
+	return order
+}
+
+// DoubleThreats cuenta las casillas vacías donde una piedra de 'player' crea
+// dos amenazas simultáneas en direcciones distintas
+// Un "cuatro" es una cadena de WinLength-2 o WinLength-1 piedras con algún
+// extremo libre; un "tres" es una cadena de WinLength-3 con ambos extremos libres
+// Parámetros:
+// - b: Tablero actual
+// - player: Jugador cuyas combinaciones se buscan
+// Retorna: Casillas con dos o más cuatros (fourFour), con un cuatro y algún tres
+// (fourThree) y con dos o más treses sin cuatro (threeThree); cada casilla cuenta
+// en una sola categoría, la más fuerte
+func DoubleThreats(b Board, player rune) (fourFour, fourThree, threeThree int) {
+	return DefaultRules.DoubleThreats(b, player)
+}
+
+// DoubleThreats mide las amenazas con rules.WinLength
+func (rules Rules) DoubleThreats(b Board, player rune) (fourFour, fourThree, threeThree int) {
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			if b[r][c] != '\x00' {
+				continue
+			}
+			fours, threes := 0, 0
+			for _, d := range chainDirections {
+				ahead := countDirection(&b, r, c, d.dr, d.dc, player)
+				behind := countDirection(&b, r, c, -d.dr, -d.dc, player)
+				length := 1 + ahead + behind
+				openEnds := 0
+				if isEmptyAt(&b, r+d.dr*(ahead+1), c+d.dc*(ahead+1)) {
+					openEnds++
+				}
+				if isEmptyAt(&b, r-d.dr*(behind+1), c-d.dc*(behind+1)) {
+					openEnds++
+				}
+				switch {
+				case length >= rules.WinLength-2 && length < rules.WinLength && openEnds > 0:
+					fours++
+				case length == rules.WinLength-3 && openEnds == 2:
+					threes++
+				}
+			}
+			switch {
+			case fours >= 2:
+				fourFour++
+			case fours == 1 && threes >= 1:
+				fourThree++
+			case threes >= 2:
+				threeThree++
+			}
+		}
+	}
+	return fourFour, fourThree, threeThree
+}
+
+// isEmptyAt indica si (r,c) está dentro del tablero y vacía
+func isEmptyAt(b *Board, r, c int) bool {
+	return r >= 0 && r < BoardSize && c >= 0 && c < BoardSize && b[r][c] == '\x00'
+}
+
+// doubleThreatScore es la bonificación de EvaluateBoard por las dobles amenazas
+// de 'player': cada categoría presente suma su peso una sola vez
+func (rules Rules) doubleThreatScore(b Board, player rune) int {
+	w := rules.weights()
+	if w.FourFour == 0 && w.FourThree == 0 && w.ThreeThree == 0 {
+		return 0
+	}
+	fourFour, fourThree, threeThree := rules.DoubleThreats(b, player)
+	score := 0
+	if fourFour > 0 {
+		score += w.FourFour
+	}
+	if fourThree > 0 {
+		score += w.FourThree
+	}
+	if threeThree > 0 {
+		score += w.ThreeThree
+	}
+	return score
+}
@@ -0,0 +1,170 @@
+package board
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestFindOpenThreesUsesWinLength: los tres abiertos son cadenas de
+// WinLength-3 piedras con ambos extremos libres, según las reglas
+func TestFindOpenThreesUsesWinLength(t *testing.T) {
+	var b Board
+	for c := 5; c <= 7; c++ {
+		b[5][c] = 'W'
+	}
+	b[10][5], b[10][6] = 'W', 'W'
+
+	got := FindOpenThrees(b, 'W')
+	want := []Position{{5, 4}, {5, 8}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("FindOpenThrees = %v, want %v", got, want)
+	}
+
+	// En Connect5 el "tres" es la pareja de la fila 10
+	got = Rules{WinLength: 5}.FindOpenThrees(b, 'W')
+	want = []Position{{10, 4}, {10, 7}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Connect5: FindOpenThrees = %v, want %v", got, want)
+	}
+}
+
+// TestThreatMapPeaksAtFive: la casilla que completa cinco en línea tiene el valor
+// más alto del mapa y las ocupadas valen 0
+func TestThreatMapPeaksAtFive(t *testing.T) {
+	var b Board
+	for c := 5; c <= 8; c++ {
+		b[9][c] = 'B'
+	}
+	b[9][4] = 'W' // solo (9,9) completa el cinco
+	b[3][3], b[3][4] = 'B', 'B'
+
+	threats := ThreatMap(b, 'B')
+	if threats[9][9] != 5 {
+		t.Errorf("ThreatMap[9][9] = %d, want 5", threats[9][9])
+	}
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			if (r != 9 || c != 9) && threats[r][c] >= threats[9][9] {
+				t.Errorf("ThreatMap[%d][%d] = %d no es menor que la casilla del cinco", r, c, threats[r][c])
+			}
+			if b[r][c] != 0 && threats[r][c] != 0 {
+				t.Errorf("casilla ocupada (%d,%d) vale %d", r, c, threats[r][c])
+			}
+		}
+	}
+	if threats[3][5] != 3 {
+		t.Errorf("ThreatMap[3][5] = %d, want 3", threats[3][5])
+	}
+}
+
+// TestDoubleThreatsCategories: una piedra en (9,9) cruza una fila con una columna
+// de negras; según sus longitudes la casilla es un cuatro-cuatro, un cuatro-tres
+// o un tres-tres, solo cuenta en la categoría más fuerte y EvaluateBoard suma su peso
+func TestDoubleThreatsCategories(t *testing.T) {
+	// cross pone 'row' negras a la izquierda de (9,9) y 'col' encima
+	cross := func(row, col int) Board {
+		var b Board
+		for i := 1; i <= row; i++ {
+			b[9][9-i] = 'B'
+		}
+		for i := 1; i <= col; i++ {
+			b[9-i][9] = 'B'
+		}
+		return b
+	}
+
+	for _, tc := range []struct {
+		name                            string
+		b                               Board
+		fourFour, fourThree, threeThree int
+	}{
+		{"cuatro-cuatro", cross(3, 3), 1, 0, 0},
+		{"cuatro-tres", cross(3, 2), 0, 1, 0},
+		{"tres-tres", cross(2, 2), 0, 0, 1},
+		{"sin cruce", cross(2, 0), 0, 0, 0},
+	} {
+		ff, ft, tt := DoubleThreats(tc.b, 'B')
+		if ff != tc.fourFour || ft != tc.fourThree || tt != tc.threeThree {
+			t.Errorf("%s: DoubleThreats = %d, %d, %d; want %d, %d, %d",
+				tc.name, ff, ft, tt, tc.fourFour, tc.fourThree, tc.threeThree)
+		}
+		if ff, ft, tt := DoubleThreats(tc.b, 'W'); ff+ft+tt != 0 {
+			t.Errorf("%s: las blancas no tienen piedras y suman %d dobles amenazas", tc.name, ff+ft+tt)
+		}
+
+		// EvaluateBoard suma el peso de la categoría presente
+		noBonus := DefaultWeights
+		noBonus.FourFour, noBonus.FourThree, noBonus.ThreeThree = 0, 0, 0
+		want := float64(tc.fourFour*DefaultWeights.FourFour + tc.fourThree*DefaultWeights.FourThree + tc.threeThree*DefaultWeights.ThreeThree)
+		if got := EvaluateBoard(tc.b, 'B') - DefaultRules.WithWeights(noBonus).EvaluateBoard(tc.b, 'B'); got != want {
+			t.Errorf("%s: bonificación en EvaluateBoard = %.0f, want %.0f", tc.name, got, want)
+		}
+	}
+}
+
+// TestFindCriticalBlocksNThreshold: con umbral 3 el tres abierto da sus dos
+// extremos y la pareja abierta también los suyos; con el umbral histórico
+// (4) la pareja deja de ser crítica. Las piedras sueltas y las propias no cuentan
+func TestFindCriticalBlocksNThreshold(t *testing.T) {
+	var b Board
+	for c := 5; c <= 7; c++ {
+		b[5][c] = 'W' // tres abierto
+	}
+	b[12][8], b[12][9] = 'W', 'W' // pareja abierta
+	b[2][2] = 'W'
+	b[15][3], b[15][4], b[15][5] = 'B', 'B', 'B'
+
+	want3 := []Position{{5, 4}, {5, 8}, {12, 7}, {12, 10}}
+	if got := FindCriticalBlocksN(b, 'W', 3); !reflect.DeepEqual(got, want3) {
+		t.Errorf("umbral 3: %v, want %v", got, want3)
+	}
+	want4 := []Position{{5, 4}, {5, 8}}
+	if got := FindCriticalBlocksN(b, 'W', 4); !reflect.DeepEqual(got, want4) {
+		t.Errorf("umbral 4: %v, want %v", got, want4)
+	}
+	if got := FindCriticalBlocks(b, 'W'); !reflect.DeepEqual(got, want4) {
+		t.Errorf("FindCriticalBlocks: %v, want %v (umbral 4)", got, want4)
+	}
+}
+
+// TestSplitFourDetection: "WW.WW" no tiene ninguna cadena de 4, pero con las
+// dos piedras del turno completa seis; sus huecos salen en FindSplitFours y
+// en FindCriticalBlocks. Bloquear un extremo no basta, el hueco central sí
+func TestSplitFourDetection(t *testing.T) {
+	var b Board
+	b[9][5], b[9][6], b[9][8], b[9][9] = 'W', 'W', 'W', 'W'
+	b[3][3] = 'B'
+
+	contains := func(ps []Position, p Position) bool {
+		for _, q := range ps {
+			if q == p {
+				return true
+			}
+		}
+		return false
+	}
+	want := []Position{{9, 4}, {9, 7}, {9, 10}}
+	if got := FindSplitFours(b, 'W'); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindSplitFours = %v, want %v", got, want)
+	}
+	blocks := FindCriticalBlocks(b, 'W')
+	for _, p := range want {
+		if !contains(blocks, p) {
+			t.Errorf("FindCriticalBlocks = %v, falta %v", blocks, p)
+		}
+	}
+	if got := FindSplitFours(b, 'B'); len(got) != 0 {
+		t.Errorf("FindSplitFours de las negras = %v", got)
+	}
+
+	edge := b
+	edge[9][10] = 'B'
+	if got := FindSplitFours(edge, 'W'); !reflect.DeepEqual(got, []Position{{9, 4}, {9, 7}}) {
+		t.Errorf("con (9,10) bloqueada: %v, want [(9,4) (9,7)]", got)
+	}
+	center := b
+	center[9][7] = 'B'
+	if got := FindSplitFours(center, 'W'); len(got) != 0 {
+		t.Errorf("con el hueco central bloqueado quedan %v", got)
+	}
+}
@@ -0,0 +1,89 @@
+package board
+
+import (
+	"math/rand"
+	"time"
+)
+
+// zobristKeys guarda una clave aleatoria de 64 bits por celda y color
+// [fila][columna][0]: negras, [fila][columna][1]: blancas
+var zobristKeys [BoardSize][BoardSize][2]uint64
+
+// zobristSide es la clave del turno: se suma cuando juegan las blancas
+var zobristSide uint64
+
+func init() {
+	ZobristInit(time.Now().UnixNano())
+}
+
+// ZobristInit regenera la tabla de claves Zobrist a partir de una semilla
+// Parámetros:
+// - seed: Semilla del generador (misma semilla => mismos hashes)
+// Nota: los hashes calculados antes de llamarla dejan de ser comparables
+func ZobristInit(seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			zobristKeys[r][c][0] = rng.Uint64()
+			zobristKeys[r][c][1] = rng.Uint64()
+		}
+	}
+	zobristSide = rng.Uint64()
+}
+
+// ZobristHash calcula el hash Zobrist del tablero
+// Parámetros:
+// - b: Tablero actual
+// Retorna: XOR de las claves de todas las piedras; no depende del orden de las jugadas
+func ZobristHash(b Board) uint64 {
+	var h uint64
+	for r := 0; r < BoardSize; r++ {
+		for c := 0; c < BoardSize; c++ {
+			switch b[r][c] {
+			case 'B':
+				h ^= zobristKeys[r][c][0]
+			case 'W':
+				h ^= zobristKeys[r][c][1]
+			}
+		}
+	}
+	return h
+}
+
+// ZobristHashToMove calcula el hash Zobrist del tablero incluyendo el turno
+// Parámetros:
+// - b: Tablero actual
+// - toMove: Jugador al que le toca mover ('B' o 'W')
+// Retorna: ZobristHash(b), con la clave del turno si juegan las blancas
+func ZobristHashToMove(b Board, toMove rune) uint64 {
+	h := ZobristHash(b)
+	if toMove == 'W' {
+		h ^= zobristSide
+	}
+	return h
+}
+
+// ZobristUpdate actualiza un hash tras colocar (o quitar) las piedras de un turno
+// Parámetros:
+// - h: Hash de la posición anterior (ZobristHash o ZobristHashToMove)
+// - move: Piedras del turno; una posición NoPosition se ignora
+// - player: Jugador que colocó las piedras
+// Retorna: El hash de la posición nueva, sin recorrer el tablero. Para un hash
+// con turno, se cambia además con ZobristSwitchSide
+func ZobristUpdate(h uint64, move Move, player rune) uint64 {
+	color := 0
+	if player == 'W' {
+		color = 1
+	}
+	for _, p := range move {
+		if p != NoPosition {
+			h ^= zobristKeys[p.Row][p.Col][color]
+		}
+	}
+	return h
+}
+
+// ZobristSwitchSide cambia el turno de un hash de ZobristHashToMove
+func ZobristSwitchSide(h uint64) uint64 {
+	return h ^ zobristSide
+}
@@ -0,0 +1,75 @@
+package board
+
+import (
+	"testing"
+)
+
+// TestZobristIncrementalMatchesRecompute: actualizar el hash jugada a jugada
+// da lo mismo que recalcularlo desde el tablero, con y sin el turno
+func TestZobristIncrementalMatchesRecompute(t *testing.T) {
+	ZobristInit(42)
+	turns := []struct {
+		player rune
+		move   Move
+	}{
+		{'B', Move{{9, 9}, NoPosition}},
+		{'W', Move{{9, 10}, {10, 10}}},
+		{'B', Move{{8, 8}, {10, 8}}},
+		{'W', Move{{0, 0}, {18, 18}}},
+	}
+
+	var b Board
+	h, side := ZobristHash(b), ZobristHashToMove(b, 'B')
+	for i, turn := range turns {
+		ApplyMove(&b, turn.move, turn.player)
+		h = ZobristUpdate(h, turn.move, turn.player)
+		side = ZobristSwitchSide(ZobristUpdate(side, turn.move, turn.player))
+		if want := ZobristHash(b); h != want {
+			t.Errorf("turno %d: incremental %x, recalculado %x", i, h, want)
+		}
+		if want := ZobristHashToMove(b, SwitchPlayer(turn.player)); side != want {
+			t.Errorf("turno %d con turno: incremental %x, recalculado %x", i, side, want)
+		}
+	}
+
+	// Quitar las piedras del último turno vuelve al hash anterior
+	last := turns[len(turns)-1]
+	b[0][0], b[18][18] = 0, 0
+	if got := ZobristUpdate(h, last.move, last.player); got != ZobristHash(b) {
+		t.Errorf("deshacer el turno: %x, want %x", got, ZobristHash(b))
+	}
+}
+
+// TestZobristDistinguishesPositions: el mismo tablero por otro orden de
+// jugadas da el mismo hash; otra posición, otro color o el otro turno, no
+func TestZobristDistinguishesPositions(t *testing.T) {
+	ZobristInit(7)
+	var a, b Board
+	ApplyMove(&a, Move{{9, 9}, NoPosition}, 'B')
+	ApplyMove(&a, Move{{9, 10}, {10, 10}}, 'W')
+	ApplyMove(&b, Move{{9, 9}, NoPosition}, 'B')
+	ApplyMove(&b, Move{{10, 10}, {9, 10}}, 'W') // las mismas piedras en otro orden
+	if ZobristHash(a) != ZobristHash(b) {
+		t.Error("transposición: los hashes difieren")
+	}
+
+	moved := a
+	moved[10][10], moved[11][11] = 0, 'W'
+	swapped := a
+	swapped[9][10], swapped[9][9] = 'B', 'W'
+	seen := map[uint64]string{ZobristHash(a): "original"}
+	for name, other := range map[string]Board{"piedra movida": moved, "colores cambiados": swapped, "vacío": {}} {
+		h := ZobristHash(other)
+		if prev, dup := seen[h]; dup {
+			t.Errorf("%s: mismo hash que %s", name, prev)
+		}
+		seen[h] = name
+	}
+
+	if ZobristHashToMove(a, 'B') == ZobristHashToMove(a, 'W') {
+		t.Error("el turno no cambia el hash")
+	}
+	if ZobristHashToMove(a, 'B') != ZobristHash(a) {
+		t.Error("con negras al turno el hash no es ZobristHash")
+	}
+}
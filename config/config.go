@@ -0,0 +1,137 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"connect6/mcts"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Settings son los ajustes del motor y de la interfaz leídos de un archivo
+// Solo cuentan los que el archivo define (ver Has); el resto conserva el
+// valor de las banderas o del motor
+type Settings struct {
+	Iterations  int     // "iterations": mcts.Iterations
+	TimeLimit   int     // "tpj": segundos por jugada (mcts.TimeLimit)
+	Exploration float64 // "exploration": mcts.Exploration
+	Difficulty  string  // "difficulty": easy, medium o hard (lo valida main)
+	Lang        string  // "lang": idioma de los mensajes, "es" o "en" (lo valida main)
+	Color       bool    // "color": tablero con colores ANSI
+
+	set map[string]bool // claves presentes en el archivo
+}
+
+// Load lee los ajustes de 'path'
+// Formatos aceptados:
+//   - JSON: un objeto {"iterations": 50000, "lang": "en", "color": true}
+//   - clave=valor, una por línea; las líneas vacías y las que empiezan con '#'
+//     se ignoran
+//
+// Las claves son las de Settings (iguales a las banderas de main cuando existen)
+// Retorna: error si el archivo no se puede leer, una clave es desconocida o un
+// valor es inválido
+func Load(path string) (Settings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Settings{}, err
+	}
+	return Parse(data)
+}
+
+// Parse interpreta el contenido de un archivo de ajustes (ver Load)
+func Parse(data []byte) (Settings, error) {
+	s := Settings{set: make(map[string]bool)}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		// UseNumber conserva los números como texto: 1000000 no pasa a "1e+06"
+		var values map[string]interface{}
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.UseNumber()
+		if err := dec.Decode(&values); err != nil {
+			return Settings{}, err
+		}
+		for key, value := range values {
+			if err := s.Set(key, fmt.Sprint(value)); err != nil {
+				return Settings{}, err
+			}
+		}
+		return s, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return Settings{}, fmt.Errorf("línea %d: se esperaba clave=valor", lineNo)
+		}
+		if err := s.Set(strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+			return Settings{}, fmt.Errorf("línea %d: %v", lineNo, err)
+		}
+	}
+	return s, scanner.Err()
+}
+
+// Set asigna el ajuste 'key' a partir de su texto y lo marca como presente
+// difficulty y lang se guardan tal cual: main los valida igual que sus banderas
+// Retorna: error si la clave es desconocida o el valor es inválido
+func (s *Settings) Set(key, value string) error {
+	var err error
+	switch key {
+	case "iterations":
+		s.Iterations, err = strconv.Atoi(value)
+		if err == nil && s.Iterations <= 0 {
+			err = fmt.Errorf("debe ser mayor que 0")
+		}
+	case "tpj":
+		s.TimeLimit, err = strconv.Atoi(value)
+		if err == nil && s.TimeLimit <= 0 {
+			err = fmt.Errorf("debe ser mayor que 0")
+		}
+	case "exploration":
+		s.Exploration, err = strconv.ParseFloat(value, 64)
+		if err == nil && s.Exploration < 0 {
+			err = fmt.Errorf("no puede ser negativa")
+		}
+	case "difficulty":
+		s.Difficulty = value
+	case "lang":
+		s.Lang = value
+	case "color":
+		s.Color, err = strconv.ParseBool(value)
+	default:
+		return fmt.Errorf("clave desconocida %q", key)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %v", key, err)
+	}
+	if s.set == nil {
+		s.set = make(map[string]bool)
+	}
+	s.set[key] = true
+	return nil
+}
+
+// Has indica si el ajuste 'key' se definió (en el archivo o con Set)
+func (s Settings) Has(key string) bool {
+	return s.set[key]
+}
+
+// ApplyEngine copia al motor los ajustes definidos de iterations, tpj y exploration
+func (s Settings) ApplyEngine(m *mcts.MCTS) {
+	if s.Has("iterations") {
+		m.Iterations = s.Iterations
+	}
+	if s.Has("tpj") {
+		m.TimeLimit = s.TimeLimit
+	}
+	if s.Has("exploration") {
+		m.Exploration = s.Exploration
+	}
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"connect6/mcts"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestLoadAppliesSettings carga el mismo archivo en ambos formatos y comprueba
+// los ajustes del motor y de la interfaz
+func TestLoadAppliesSettings(t *testing.T) {
+	files := map[string]string{
+		"ajustes.json": `{"iterations": 1000000, "tpj": 7, "exploration": 0.8, "difficulty": "medium", "lang": "en", "color": true}`,
+		"ajustes.txt":  "# motor\niterations = 1000000\ntpj=7\nexploration=0.8\n\ndifficulty=medium\nlang=en\ncolor=true\n",
+	}
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		s, err := Load(path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		m := mcts.NewMCTS(1, 10, 30, 1)
+		s.ApplyEngine(m)
+		if m.Iterations != 1000000 || m.TimeLimit != 7 || m.Exploration != 0.8 {
+			t.Errorf("%s: motor con iterations=%d tpj=%d exploration=%v", name, m.Iterations, m.TimeLimit, m.Exploration)
+		}
+		if s.Difficulty != "medium" || s.Lang != "en" || !s.Color {
+			t.Errorf("%s: difficulty=%q lang=%q color=%v", name, s.Difficulty, s.Lang, s.Color)
+		}
+		for _, key := range []string{"iterations", "tpj", "exploration", "difficulty", "lang", "color"} {
+			if !s.Has(key) {
+				t.Errorf("%s: falta la clave %q", name, key)
+			}
+		}
+	}
+}
+
+// TestApplyEngineKeepsUnsetValues comprueba que las claves ausentes no tocan el motor
+func TestApplyEngineKeepsUnsetValues(t *testing.T) {
+	s, err := Parse([]byte("tpj=3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := mcts.NewMCTS(1, 10, 30, 1)
+	exploration := m.Exploration
+	s.ApplyEngine(m)
+	if m.TimeLimit != 3 || m.Iterations != 10 || m.Exploration != exploration {
+		t.Errorf("motor con iterations=%d tpj=%d exploration=%v", m.Iterations, m.TimeLimit, m.Exploration)
+	}
+}
+
+// TestParseRejectsInvalid comprueba los errores de clave y de valor
+func TestParseRejectsInvalid(t *testing.T) {
+	for _, data := range []string{"foo=1", "iterations=0", "tpj=x", "exploration=-1", "color=quizá", "sin igual"} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Parse(%q) sin error", data)
+		}
+	}
+}
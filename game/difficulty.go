@@ -0,0 +1,61 @@
+package game
+
+import (
+	"connect6/mcts"
+)
+
+// Niveles de dificultad aceptados por NewGame
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard" // por defecto: la configuración original del bot
+)
+
+// ValidDifficulty indica si 'level' es uno de los niveles de dificultad
+func ValidDifficulty(level string) bool {
+	switch level {
+	case DifficultyEasy, DifficultyMedium, DifficultyHard:
+		return true
+	}
+	return false
+}
+
+// applyDifficulty ajusta los parámetros del motor según la dificultad
+// Mapeo exacto (TimeLimit nunca supera 'tiempo', el valor de -tpj):
+//
+//	nivel   Iterations  MaxDepth  TimeLimit       Temperature
+//	easy    2000        10        min(tiempo, 1)  1.0 (elige al azar según visitas)
+//	medium  20000       20        min(tiempo, 2)  0
+//	hard    100000      30        tiempo          0
+//
+// Retorna false, sin tocar el motor, si 'dificultad' no es un nivel válido
+func applyDifficulty(engine *mcts.MCTS, dificultad string, tiempo int) bool {
+	switch dificultad {
+	case DifficultyEasy:
+		engine.Iterations = 2000
+		engine.MaxDepth = 10
+		engine.TimeLimit = minInt(tiempo, 1)
+		engine.Temperature = 1.0
+	case DifficultyMedium:
+		engine.Iterations = 20000
+		engine.MaxDepth = 20
+		engine.TimeLimit = minInt(tiempo, 2)
+		engine.Temperature = 0
+	case DifficultyHard:
+		engine.Iterations = 100000
+		engine.MaxDepth = 30
+		engine.TimeLimit = tiempo
+		engine.Temperature = 0
+	default:
+		return false
+	}
+	return true
+}
+
+// minInt retorna el menor de dos enteros
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
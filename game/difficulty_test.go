@@ -0,0 +1,61 @@
+package game
+
+import (
+	"testing"
+
+	"connect6/mcts"
+)
+
+// TestApplyDifficultyLevels: cada nivel deja las iteraciones, la profundidad,
+// el tiempo y la temperatura de la tabla de applyDifficulty, también a través
+// de NewGame; un nivel desconocido se rechaza sin tocar el motor
+func TestApplyDifficultyLevels(t *testing.T) {
+	cases := []struct {
+		level       string
+		iterations  int
+		depth       int
+		timeLimit   int
+		temperature float64
+	}{
+		{DifficultyEasy, 2000, 10, 1, 1.0},
+		{DifficultyMedium, 20000, 20, 2, 0},
+		{DifficultyHard, 100000, 30, 5, 0},
+	}
+	for _, tc := range cases {
+		if !ValidDifficulty(tc.level) {
+			t.Errorf("%s: ValidDifficulty = false", tc.level)
+		}
+		engine := mcts.NewMCTS(1, 1, 1, 5)
+		if !applyDifficulty(engine, tc.level, 5) {
+			t.Errorf("%s: applyDifficulty lo rechazó", tc.level)
+		}
+		g := NewGame("", 5, ModeBotVsBot, tc.level)
+		for name, m := range map[string]*mcts.MCTS{"applyDifficulty": engine, "NewGame": g.MCTS()} {
+			if m.Iterations != tc.iterations || m.MaxDepth != tc.depth || m.TimeLimit != tc.timeLimit || m.Temperature != tc.temperature {
+				t.Errorf("%s %s: Iterations %d, MaxDepth %d, TimeLimit %d, Temperature %v; want %d, %d, %d, %v",
+					name, tc.level, m.Iterations, m.MaxDepth, m.TimeLimit, m.Temperature,
+					tc.iterations, tc.depth, tc.timeLimit, tc.temperature)
+			}
+		}
+	}
+
+	// El tiempo por jugada limita a easy y medium
+	engine := mcts.NewMCTS(1, 1, 1, 1)
+	applyDifficulty(engine, DifficultyMedium, 1)
+	if engine.TimeLimit != 1 {
+		t.Errorf("medium con -tpj 1: TimeLimit = %d, want 1", engine.TimeLimit)
+	}
+
+	for _, level := range []string{"", "Hard", "imposible"} {
+		if ValidDifficulty(level) {
+			t.Errorf("%q: ValidDifficulty = true", level)
+		}
+		engine := mcts.NewMCTS(1, 7, 3, 9)
+		if applyDifficulty(engine, level, 9) {
+			t.Errorf("%q: applyDifficulty lo aceptó", level)
+		}
+		if engine.Iterations != 7 || engine.MaxDepth != 3 || engine.TimeLimit != 9 {
+			t.Errorf("%q: el motor cambió a %d, %d, %d", level, engine.Iterations, engine.MaxDepth, engine.TimeLimit)
+		}
+	}
+}
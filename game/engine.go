@@ -0,0 +1,116 @@
+package game
+
+import (
+	"connect6/board"
+	"connect6/mcts"
+	"fmt"
+	"time"
+)
+
+// EngineOptions configura un Engine; cada campo se copia al campo homónimo
+// de mcts.MCTS. Los valores cero usan los valores por defecto indicados.
+type EngineOptions struct {
+	Iterations  int     // mcts.Iterations: simulaciones máximas por búsqueda (100000)
+	MaxDepth    int     // mcts.MaxDepth: profundidad máxima del rollout (30)
+	TimeLimit   int     // mcts.TimeLimit: segundos por búsqueda (4)
+	Exploration float64 // mcts.Exploration: constante UCB (mcts.DefaultExploration)
+	Workers     int     // mcts.Workers: árboles en paralelo (1)
+	Seed        int64   // semilla de NewMCTS (0 => reloj)
+
+	// mcts.Rules: reglas de la partida; también deciden cuándo termina en
+	// Apply y el ganador de Winner (board.DefaultRules)
+	Rules board.Rules
+}
+
+// Engine permite jugar partidas sin entrada/salida por consola,
+// para integrar el motor en otros programas
+type Engine struct {
+	mcts    *mcts.MCTS
+	board   board.Board
+	toMove  rune
+	history []MoveRecord
+}
+
+// NewEngine crea un motor con un tablero vacío y negras por mover
+// Parámetros:
+//   - opts: Parámetros de búsqueda (ver EngineOptions)
+//
+// Retorna:
+//   - Puntero a Engine listo para usar
+func NewEngine(opts EngineOptions) *Engine {
+	if opts.Iterations == 0 {
+		opts.Iterations = 100000
+	}
+	if opts.MaxDepth == 0 {
+		opts.MaxDepth = 30
+	}
+	if opts.TimeLimit == 0 {
+		opts.TimeLimit = 4
+	}
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
+	}
+
+	engine := mcts.NewMCTS(opts.Seed, opts.Iterations, opts.MaxDepth, opts.TimeLimit)
+	if opts.Exploration != 0 {
+		engine.Exploration = opts.Exploration
+	}
+	engine.Workers = opts.Workers
+	if opts.Rules.WinLength == 0 {
+		opts.Rules = board.DefaultRules
+	}
+	engine.Rules = opts.Rules
+
+	return &Engine{mcts: engine, toMove: 'B'}
+}
+
+// BestMove busca el mejor movimiento de 'player' en el tablero 'b'
+// No modifica el estado del Engine
+// Retorna: El movimiento y false si no hay movimientos legales
+func (e *Engine) BestMove(b board.Board, player rune) (board.Move, bool) {
+	return e.mcts.Search(b, player)
+}
+
+// Apply juega 'move' para el jugador al que le toca en el tablero del Engine
+// Retorna: error si la partida terminó o el movimiento es ilegal
+func (e *Engine) Apply(move board.Move) error {
+	if over, _ := e.mcts.Rules.IsTerminal(e.board); over {
+		return fmt.Errorf("la partida ya terminó")
+	}
+	if err := board.ApplyMoveChecked(&e.board, move, e.toMove); err != nil {
+		return err
+	}
+	e.history = append(e.history, MoveRecord{Player: e.toMove, Move: move})
+	e.toMove = board.SwitchPlayer(e.toMove)
+	return nil
+}
+
+// Play busca y aplica el mejor movimiento del jugador al que le toca
+// Retorna: El movimiento jugado, o error si la partida ya terminó
+func (e *Engine) Play() (board.Move, error) {
+	move, ok := e.BestMove(e.board, e.toMove)
+	if !ok {
+		return move, fmt.Errorf("no hay movimientos legales")
+	}
+	return move, e.Apply(move)
+}
+
+// Winner retorna 'B' o 'W' si hay ganador, ' ' en otro caso
+func (e *Engine) Winner() rune {
+	return e.mcts.Rules.GetWinner(e.board)
+}
+
+// Board retorna una copia del tablero actual
+func (e *Engine) Board() board.Board {
+	return e.board
+}
+
+// ToMove retorna el jugador al que le toca mover
+func (e *Engine) ToMove() rune {
+	return e.toMove
+}
+
+// History retorna las jugadas aplicadas, en orden
+func (e *Engine) History() []MoveRecord {
+	return append([]MoveRecord(nil), e.history...)
+}
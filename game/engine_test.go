@@ -0,0 +1,47 @@
+package game
+
+import (
+	"fmt"
+
+	"connect6/board"
+)
+
+// ExampleEngine juega una partida sin consola: aplica jugadas fijas con Apply
+// hasta que las negras tienen cinco en fila y deja que BestMove complete la línea
+func ExampleEngine() {
+	engine := NewEngine(EngineOptions{Iterations: 50, MaxDepth: 2, Seed: 1})
+
+	at := func(r, c int) board.Position { return board.Position{Row: r, Col: c} }
+	moves := []board.Move{
+		{at(9, 9), board.NoPosition}, // apertura de las negras: una piedra
+		{at(0, 0), at(0, 1)},
+		{at(9, 10), at(9, 11)},
+		{at(0, 3), at(0, 4)},
+		{at(9, 12), at(9, 13)}, // cinco negras de (9,9) a (9,13)
+		{at(18, 0), at(18, 2)},
+	}
+	for _, move := range moves {
+		if err := engine.Apply(move); err != nil {
+			fmt.Println("error:", err)
+			return
+		}
+	}
+	fmt.Printf("Juegan: %c, ganador: %q\n", engine.ToMove(), engine.Winner())
+
+	move, ok := engine.BestMove(engine.Board(), engine.ToMove())
+	if !ok {
+		fmt.Println("sin jugadas")
+		return
+	}
+	if err := engine.Apply(move); err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Printf("Ganador: %c tras %d jugadas\n", engine.Winner(), len(engine.History()))
+	fmt.Println("Otra jugada:", engine.Apply(board.Move{at(5, 5), at(5, 6)}))
+
+	// Output:
+	// Juegan: B, ganador: ' '
+	// Ganador: B tras 7 jugadas
+	// Otra jugada: la partida ya terminó
+}
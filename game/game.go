@@ -3,97 +3,401 @@ package game
 import (
 	"connect6/board"
 	"connect6/mcts"
+	"connect6/minimax"
 	"connect6/ui"
 	"fmt"
-	"math/rand"
 	"time"
 )
 
+// forcedWinDepth es la profundidad (turnos propios) de board.FindForcedWin
+// que el bot prueba antes de lanzar MCTS
+const forcedWinDepth = 2
+
+// Modos de juego aceptados por NewGame
+const (
+	ModeHumanVsBot   = "hvb" // humano contra bot (por defecto)
+	ModeBotVsBot     = "bvb" // el bot juega contra sí mismo
+	ModeHumanVsHuman = "hvh" // dos humanos en el mismo teclado
+)
+
+// Motores de búsqueda del bot (ver SetEngine)
+const (
+	EngineMCTS    = "mcts"    // Monte Carlo Tree Search (por defecto)
+	EngineMinimax = "minimax" // alfa-beta de profundidad fija, determinista
+)
+
 // Game representa la instancia principal del juego Connect6
-// Contiene el estado del tablero, el motor de IA y el jugador actual
+// Contiene el estado del tablero, el motor de IA, el jugador actual
+// y las fichas de cada bando
 type Game struct {
 	board         board.Board
+	empties       *board.EmptySet // casillas vacías de 'board', al día con cada jugada
 	mcts          *mcts.MCTS
+	minimax       *minimax.Minimax // si no es nil, el bot busca con él en lugar de MCTS
 	currentPlayer rune
+	humanPiece    rune
+	botPiece      rune
+	mode          string
 	tpj           int
+	history       []MoveRecord // movimientos jugados, en orden
+	showPV        bool         // imprime estadísticas y variante principal del bot
+	resigned      rune         // fichas que se rindieron (0 si nadie)
+	abandoned     bool         // la entrada se cerró (ui.InputClosed): termina sin resultado
+	maxTurns      int          // turnos tras los que la partida es empate (0 => sin límite, ver SetMaxTurns)
+	swap          bool         // ofrece intercambiar colores tras la apertura (ver SetSwap)
+	turn          int          // turnos completos jugados (cada Move es un turno)
+	rules         board.Rules  // reglas de la partida y de los motores (ver SetRules)
+	console       *ui.Console  // de donde se leen las jugadas y respuestas del humano (ver SetConsole)
+
+	// OnMove, si no es nil, se llama tras aplicar cada movimiento (humano o bot)
+	// con el jugador, el movimiento y una copia del tablero resultante; permite
+	// registrar, animar o retransmitir la partida sin tocar el bucle
+	OnMove func(player rune, move board.Move, b board.Board)
 }
 
 // NewGame crea e inicializa una nueva instancia del juego
+// Parámetros:
+//   - fichas: "negras" o "blancas" (fichas del humano en ModeHumanVsBot; las
+//     negras siempre abren con la piedra única). Cualquier otro valor son
+//     blancas: por defecto el bot abre
+//   - tiempo: Segundos máximos por jugada de la IA
+//   - modo: ModeHumanVsBot, ModeBotVsBot o ModeHumanVsHuman
+//   - dificultad: DifficultyEasy, DifficultyMedium o DifficultyHard; otro
+//     valor juega como DifficultyHard (ver ValidDifficulty)
+//
 // Retorna:
 //   - Puntero a Game configurado y listo para iniciar
-func NewGame(fichas string, tiempo int) *Game {
-	rand.Seed(time.Now().UnixNano())
+func NewGame(fichas string, tiempo int, modo string, dificultad string) *Game {
+	engine := mcts.NewMCTS(time.Now().UnixNano(), 100000, 30, tiempo)
+	engine.ReuseTree = true
+	// El bot de la partida simula con la política heurística (más fuerte que el azar)
+	engine.RolloutPolicy = engine.HeuristicRollout
+	if !applyDifficulty(engine, dificultad, tiempo) {
+		applyDifficulty(engine, DifficultyHard, tiempo) // nivel desconocido: hard
+	}
 
-	// Decide quién inicia, según '-fichas='
-	var initialPlayer rune
-	if fichas == "blancas" {
-		initialPlayer = 'W'
-	} else {
-		// default: negras
-		initialPlayer = 'B'
-	}
-
-	return &Game{
-		mcts: &mcts.MCTS{
-			MaxDepth:    30,
-			Iterations:  100000,
-			Exploration: 1.414, // sqrt(2)
-			TimeLimit:   tiempo,
-		},
-		currentPlayer: initialPlayer,
+	g := &Game{
+		empties:       board.NewEmptySet(board.Board{}),
+		mcts:          engine,
+		currentPlayer: 'B',
+		mode:          modo,
 		tpj:           tiempo,
+		rules:         board.DefaultRules,
+		console:       ui.StdConsole(),
+	}
+	// Según '-fichas=' (default: blancas, el bot abre con negras)
+	if fichas == "negras" {
+		g.SetHumanPiece('B')
+	} else {
+		g.SetHumanPiece('W')
+	}
+	return g
+}
+
+// SetConsole hace que el humano juegue por 'c' en lugar de la entrada estándar;
+// los comandos de 'c' evalúan con las reglas de la partida
+func (g *Game) SetConsole(c *ui.Console) {
+	g.console = c
+	g.console.SetRules(g.rules)
+}
+
+// SetHumanPiece asigna las fichas del humano ('B' o 'W') en ModeHumanVsBot;
+// el bot juega con las otras. Si son las negras, el humano hace la apertura
+func (g *Game) SetHumanPiece(piece rune) {
+	g.humanPiece = piece
+	g.botPiece = board.SwitchPlayer(piece)
+}
+
+// SetPosition hace que la partida empiece desde 'b' en lugar del tablero vacío
+// El turno se deduce del tablero (board.GetCurrentPlayer) y el historial queda vacío
+func (g *Game) SetPosition(b board.Board) {
+	g.board = b
+	g.empties.Reset(b)
+	g.history = nil
+	g.currentPlayer = board.GetCurrentPlayer(b)
+	g.turn = turnsFromStones(board.StoneCount(b))
+}
+
+// SetMaxTurns limita la partida a 'turns' turnos completos: si se alcanzan
+// sin ganador, termina en empate (evita partidas bot contra bot que no se
+// resuelven). 0 quita el límite
+func (g *Game) SetMaxTurns(turns int) {
+	g.maxTurns = turns
+}
+
+// SetEngine elige el motor del bot: EngineMCTS o EngineMinimax
+// Con EngineMinimax el bot profundiza iterativamente durante los segundos por
+// jugada de NewGame; el libro de aperturas y -pv solo afectan a MCTS
+func (g *Game) SetEngine(engine string) {
+	g.minimax = nil
+	if engine == EngineMinimax {
+		g.minimax = minimax.New(0)
+		g.minimax.TimeLimit = g.tpj
+		g.minimax.ThreatPairs = true // más profundidad sirve si puede ver las amenazas dobles
+		g.minimax.Rules = g.minimaxRules()
 	}
 }
 
+// SetRules cambia las reglas de la partida (p.ej. ExactWinLength o los topes
+// de generación): con ellas se decide el final y el ganador, y las usan los
+// dos motores del bot y los comandos 'eval' y 'hint' de la consola. Minimax
+// conserva sus propios topes de generación
+func (g *Game) SetRules(rules board.Rules) {
+	g.rules = rules
+	g.mcts.Rules = rules
+	g.console.SetRules(rules)
+	g.mcts.ClearCache()
+	if g.minimax != nil {
+		g.minimax.Rules = g.minimaxRules()
+	}
+}
+
+// minimaxRules retorna g.rules con los topes de generación de g.minimax
+func (g *Game) minimaxRules() board.Rules {
+	return g.rules.WithMoveCaps(g.minimax.Rules.MaxPairs, g.minimax.Rules.MaxMoves)
+}
+
+// MCTS retorna el motor de búsqueda del bot, para ajustar sus parámetros
+func (g *Game) MCTS() *mcts.MCTS {
+	return g.mcts
+}
+
+// SetBook asigna el libro de aperturas que consulta el bot antes de buscar
+func (g *Game) SetBook(book map[uint64]board.Move) {
+	g.mcts.Book = book
+}
+
+// SetShowPV activa la impresión de las estadísticas de búsqueda tras cada jugada del bot
+func (g *Game) SetShowPV(on bool) {
+	g.showPV = on
+}
+
 // Run ejecuta el bucle principal del juego
 // Flujo:
 //  1. Muestra el tablero
 //  2. Verifica victoria
 //  3. Alterna turnos entre jugador y IA
-//  4. Finaliza cuando hay un ganador
+//  4. Finaliza cuando hay un ganador, un jugador se rinde, se cierra la entrada, el tablero se llena,
+//     se alcanza el límite de turnos (SetMaxTurns, empate)
+//     o el bot no encuentra movimientos legales (empate)
 func (g *Game) Run() {
 	for {
-		ui.PrintBoard(g.board)
+		if g.resigned != 0 || g.abandoned {
+			break
+		}
+		ui.ClearScreen()
+		g.printBoard()
+		ui.ShowTurn(g.turn+1, board.StoneCount(g.board))
 
-		if board.CheckWin(g.board, 'B') || board.CheckWin(g.board, 'W') {
+		// Victoria o tablero lleno sin ganador (empate)
+		if over, _ := g.rules.IsTerminal(g.board); over {
+			break
+		}
+		// Límite de turnos alcanzado sin ganador => empate
+		if g.turnCapReached() {
 			break
 		}
 
-		if g.currentPlayer == 'B' {
-			g.botTurn()
-		} else {
-			g.playerTurn()
+		if g.isBot(g.currentPlayer) {
+			if !g.botTurn(g.currentPlayer) {
+				break
+			}
+		} else if !g.playerTurn(g.currentPlayer) {
+			// Se cargó otra posición (el turno ya se recalculó) o la jugada no se aplicó
+			continue
 		}
 
 		g.currentPlayer = board.SwitchPlayer(g.currentPlayer)
+		if g.swapDue() {
+			g.offerSwap()
+		}
 	}
 	g.showFinalResult()
 }
 
+// turnCapReached indica si se jugaron los turnos permitidos por SetMaxTurns
+func (g *Game) turnCapReached() bool {
+	return g.maxTurns > 0 && g.turn >= g.maxTurns
+}
+
+// isBot indica si las fichas 'player' las controla la IA en el modo actual
+func (g *Game) isBot(player rune) bool {
+	switch g.mode {
+	case ModeBotVsBot:
+		return true
+	case ModeHumanVsHuman:
+		return false
+	}
+	return player == g.botPiece
+}
+
 // botTurn maneja el turno de la IA
 // Pasos:
-//  1. Ejecuta la búsqueda MCTS para encontrar el mejor movimiento
-//  2. Aplica el movimiento al tablero
-func (g *Game) botTurn() {
-	fmt.Println("Turno del Bot (Negras)...")
-	bestMove := g.mcts.Search(g.board) // Obtiene mejor movimiento de la IA
-	board.ApplyMove(&g.board, bestMove, 'B')
+//  1. Busca una victoria forzada por amenazas (board.FindForcedWin, que solo
+//     conoce la victoria estándar: con otras reglas se omite)
+//  2. Si no la hay, busca con el motor elegido (MCTS o minimax, ver SetEngine)
+//     para las fichas 'piece'
+//  3. Aplica el movimiento al tablero con esas mismas fichas
+//
+// Retorna false si no hay movimientos legales o la jugada elegida es ilegal
+// (la partida debe terminar)
+func (g *Game) botTurn(piece rune) bool {
+	fmt.Println(ui.Tf("bot.turn", ui.PieceName(piece)))
+	if line, ok := g.forcedWin(piece); ok {
+		fmt.Println(ui.T("bot.forced"))
+		if err := g.applyMove(line[0], piece); err != nil {
+			fmt.Println(ui.Tf("bot.error", ui.MoveErrorText(err)))
+			return false
+		}
+		ui.ShowMove(piece, line[0])
+		return true
+	}
+
+	if g.minimax != nil {
+		move, ok := g.minimax.Search(g.board, piece)
+		return g.playBotMove(piece, move, ok)
+	}
+
+	bestMove, info, ok := g.mcts.SearchWithInfo(g.board, piece) // Obtiene mejor movimiento de la IA
+	if !g.playBotMove(piece, bestMove, ok) {
+		return false
+	}
+	if g.showPV {
+		ui.ShowSearchInfo(info)
+	}
+	return true
+}
+
+// forcedWin busca con board.FindForcedWin una victoria forzada de 'piece';
+// solo con la victoria estándar (WinLength piedras, sobrelíneas válidas), que
+// es la única que conoce la búsqueda de amenazas
+func (g *Game) forcedWin(piece rune) ([]board.Move, bool) {
+	if g.rules.WinLength != board.WinLength || g.rules.ExactWinLength {
+		return nil, false
+	}
+	return board.FindForcedWin(g.board, piece, forcedWinDepth)
+}
+
+// playBotMove aplica y muestra el movimiento elegido por el bot
+// Retorna false si no había movimiento ('ok' falso) o es ilegal
+func (g *Game) playBotMove(piece rune, bestMove board.Move, ok bool) bool {
+	if !ok {
+		fmt.Println(ui.T("bot.nomoves"))
+		return false
+	}
+	if err := g.applyMove(bestMove, piece); err != nil {
+		fmt.Println(ui.Tf("bot.error", ui.MoveErrorText(err)))
+		return false
+	}
+	ui.ShowMove(piece, bestMove)
+	return true
 }
 
 // playerTurn maneja el turno del jugador humano
 // Pasos:
-//  1. Solicita entrada al jugador
-//  2. Valida y aplica el movimiento
-func (g *Game) playerTurn() {
-	fmt.Println("Tu turno (Blancas)")
-	move := ui.GetPlayerMove(g.board) // Obtiene movimiento del jugador
-	board.ApplyMove(&g.board, move, 'W')
+//  1. Avisa (solo informativo) si hay casillas que debe bloquear para no perder
+//  2. Solicita entrada al jugador
+//  3. Valida y aplica el movimiento
+//
+// Retorna false si en lugar de mover se cargó otra posición ('load'), en cuyo
+// caso el historial se reinicia y el turno se recalcula del tablero, o si la
+// jugada no se pudo aplicar (se muestra el error y el turno se repite)
+func (g *Game) playerTurn(piece rune) bool {
+	fmt.Println(ui.Tf("human.turn", ui.PieceName(piece)))
+	// Aviso previo: el rival amenaza completar la línea si no se bloquea
+	ui.ShowBlockWarning(board.FindCriticalBlocks(g.board, board.SwitchPlayer(piece)))
+	move, ok := g.console.GetPlayerMove(&g.board, piece) // Obtiene movimiento del jugador
+	if !ok {
+		g.empties.Reset(g.board)
+		g.history = nil
+		g.currentPlayer = board.GetCurrentPlayer(g.board)
+		g.turn = turnsFromStones(board.StoneCount(g.board))
+		return false
+	}
+	if move == ui.InputClosed {
+		g.abandoned = true
+		return true
+	}
+	if move == board.ResignMove {
+		g.resigned = piece
+		return true
+	}
+	if err := g.applyMove(move, piece); err != nil {
+		fmt.Println(ui.Tf("error", ui.MoveErrorText(err)))
+		return false
+	}
+	return true
+}
+
+// winner devuelve el ganador de la partida: el rival de quien se rindió,
+// o el dueño de la línea ganadora (' ' si no hay ganador)
+func (g *Game) winner() rune {
+	if g.resigned != 0 {
+		return board.SwitchPlayer(g.resigned)
+	}
+	return g.rules.GetWinner(g.board)
+}
+
+// Turn retorna el número de turnos completos jugados
+func (g *Game) Turn() int {
+	return g.turn
+}
+
+// turnsFromStones calcula los turnos que llevaron a 'stones' piedras:
+// la apertura coloca una y cada turno siguiente dos
+func turnsFromStones(stones int) int {
+	return (stones + 1) / 2
+}
+
+// printBoard muestra el tablero resaltando la última jugada y,
+// si la partida terminó con victoria, la línea ganadora
+func (g *Game) printBoard() {
+	var last board.Move
+	if len(g.history) > 0 {
+		last = g.history[len(g.history)-1].Move
+	}
+	var winLine []board.Position
+	if winner := g.rules.GetWinner(g.board); winner != ' ' {
+		winLine = g.rules.WinningLine(g.board, winner)
+	}
+	ui.PrintBoardHighlighted(g.board, last, winLine)
+}
+
+// applyMove aplica el movimiento al tablero, lo añade al historial,
+// avanza el árbol de búsqueda retenido y avisa a OnMove
+// Retorna: error (sin cambiar nada) si el movimiento es ilegal
+func (g *Game) applyMove(move board.Move, piece rune) error {
+	if err := board.ApplyMoveIn(&g.board, g.empties, move, piece); err != nil {
+		return err
+	}
+	g.history = append(g.history, MoveRecord{Player: piece, Move: move})
+	g.turn++
+	g.mcts.Advance(move)
+	if g.OnMove != nil {
+		g.OnMove(piece, move, g.board)
+	}
+	return nil
 }
 
 // showFinalResult muestra el resultado final del juego
 // - Imprime el tablero final
-// - Muestra mensaje de victoria/empate
+// - Muestra mensaje de victoria/empate y la línea ganadora
 func (g *Game) showFinalResult() {
-	ui.PrintBoard(g.board)
-	ui.ShowResult(board.GetWinner(g.board))
+	g.printBoard()
+	ui.ShowGameLength(g.turn, board.StoneCount(g.board))
+	if g.abandoned {
+		fmt.Println(ui.T("abandoned"))
+		return
+	}
+	if g.resigned != 0 {
+		fmt.Println(ui.Tf("resigned", ui.PieceName(g.resigned)))
+	}
+	winner := g.winner()
+	if winner == ' ' && g.turnCapReached() {
+		fmt.Println(ui.Tf("turn.cap", g.maxTurns))
+	}
+	ui.ShowResult(winner)
+	if g.resigned == 0 && winner != ' ' {
+		ui.ShowWinningLine(g.rules.WinningLine(g.board, winner))
+	}
 }
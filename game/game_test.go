@@ -0,0 +1,280 @@
+package game
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"connect6/board"
+	"connect6/ui"
+)
+
+// TestSetRulesDecidesWinner: con ExactWinLength una sobrelínea no termina la
+// partida; con las reglas estándar, sí
+func TestSetRulesDecidesWinner(t *testing.T) {
+	var b board.Board
+	for c := 0; c < 7; c++ {
+		b[5][c] = 'B'
+	}
+	for c := 0; c < 6; c++ {
+		b[10][c*2] = 'W'
+	}
+
+	g := NewGame("negras", 1, ModeBotVsBot, DifficultyEasy)
+	g.SetPosition(b)
+	if g.winner() != 'B' {
+		t.Errorf("reglas estándar: winner = %q, want 'B'", g.winner())
+	}
+
+	g.SetEngine(EngineMinimax)
+	g.SetRules(board.Rules{WinLength: board.WinLength, ExactWinLength: true})
+	if g.winner() != ' ' {
+		t.Errorf("ExactWinLength: winner = %q, want ' '", g.winner())
+	}
+	if !g.MCTS().Rules.ExactWinLength || !g.minimax.Rules.ExactWinLength {
+		t.Error("SetRules no llegó a los motores")
+	}
+	if g.minimax.Rules.MaxPairs == 0 {
+		t.Error("SetRules perdió los topes de generación de minimax")
+	}
+}
+
+// newScriptedGame crea una partida humano contra bot en la que el humano juega
+// con 'piece' y responde con las líneas de 'input'; el bot busca poco y con
+// simulaciones al azar
+func newScriptedGame(piece rune, input string) *Game {
+	g := NewGame("", 1, ModeHumanVsBot, DifficultyEasy)
+	g.SetHumanPiece(piece)
+	g.SetConsole(ui.NewConsole(strings.NewReader(input), io.Discard))
+	g.MCTS().Iterations = 50
+	g.MCTS().MaxDepth = 2
+	g.MCTS().RolloutPolicy = nil
+	return g
+}
+
+// TestHumanAsBlackOpens: con negras, el humano hace la apertura de una piedra
+func TestHumanAsBlackOpens(t *testing.T) {
+	g := newScriptedGame('B', "9 9\n")
+	g.Run()
+	if len(g.history) < 1 || g.history[0].Player != 'B' || g.board[9][9] != 'B' {
+		t.Fatalf("la apertura no es la del humano: %+v", g.history)
+	}
+	if g.history[0].Move[1] != board.NoPosition {
+		t.Errorf("la apertura tiene dos piedras: %v", g.history[0].Move)
+	}
+	if len(g.history) != 2 || g.history[1].Player != 'W' {
+		t.Errorf("el bot no respondió con blancas: %+v", g.history)
+	}
+	if !g.abandoned {
+		t.Error("la partida no terminó al cerrarse la entrada")
+	}
+}
+
+// TestHumanAsWhiteReplies: con blancas, el bot abre y el humano responde con dos piedras
+func TestHumanAsWhiteReplies(t *testing.T) {
+	g := newScriptedGame('W', "0 0 0 1\n")
+	g.Run()
+	if len(g.history) < 2 || g.history[0].Player != 'B' || g.history[0].Move[1] != board.NoPosition {
+		t.Fatalf("el bot no abrió con una piedra negra: %+v", g.history)
+	}
+	if g.history[1].Player != 'W' || g.board[0][0] != 'W' || g.board[0][1] != 'W' {
+		t.Errorf("la respuesta del humano no se aplicó: %+v", g.history)
+	}
+	if !g.abandoned {
+		t.Error("la partida no terminó al cerrarse la entrada")
+	}
+}
+
+// TestRunStopsOnLoadedWin: una posición cargada que ya está ganada termina la
+// partida sin pedir ninguna jugada
+func TestRunStopsOnLoadedWin(t *testing.T) {
+	var b board.Board
+	for c := 0; c < 6; c++ {
+		b[3][c] = 'B'
+		b[12][c*2] = 'W'
+	}
+	b[14][0] = 'W'
+	g := newScriptedGame('W', "")
+	g.SetPosition(b)
+	g.Run()
+	if g.abandoned || len(g.history) != 0 {
+		t.Fatalf("la partida siguió tras la victoria: abandonada=%v historial=%v", g.abandoned, g.history)
+	}
+	if g.winner() != 'B' {
+		t.Errorf("winner = %q, want 'B'", g.winner())
+	}
+}
+
+// TestNewGamePlaysOneTurn: la partida que arma NewGame (como main) juega un
+// turno completo y se detiene en el límite (búsqueda corta con simulaciones al azar)
+func TestNewGamePlaysOneTurn(t *testing.T) {
+	g := NewGame("negras", 1, ModeBotVsBot, DifficultyEasy)
+	g.MCTS().Iterations = 50
+	g.MCTS().MaxDepth = 2
+	g.MCTS().RolloutPolicy = nil
+	g.SetMaxTurns(1)
+	g.Run()
+	if g.Turn() != 1 || len(g.history) != 1 {
+		t.Fatalf("turnos = %d, historial = %v", g.Turn(), g.history)
+	}
+	if g.history[0].Player != 'B' || board.StoneCount(g.board) != 1 {
+		t.Errorf("la apertura no es una piedra negra: %v", g.history[0])
+	}
+}
+
+// TestTurnCounter: cada turno completo suma uno (la apertura con una piedra,
+// los siguientes con dos) y una posición cargada deduce los turnos de sus piedras
+func TestTurnCounter(t *testing.T) {
+	g := NewGame("", 1, ModeBotVsBot, DifficultyEasy)
+	g.MCTS().Iterations = 50
+	g.MCTS().MaxDepth = 2
+	g.MCTS().RolloutPolicy = nil
+	g.SetMaxTurns(3)
+	g.Run()
+	if g.Turn() != 3 || board.StoneCount(g.board) != 5 {
+		t.Errorf("turnos = %d, piedras = %d; want 3 y 5", g.Turn(), board.StoneCount(g.board))
+	}
+
+	for stones, want := range map[int]int{0: 0, 1: 1, 3: 2, 5: 3, 7: 4} {
+		var b board.Board
+		for i := 0; i < stones; i++ {
+			b[i][0] = 'B'
+		}
+		g.SetPosition(b)
+		if g.Turn() != want {
+			t.Errorf("%d piedras: turno %d, want %d", stones, g.Turn(), want)
+		}
+	}
+}
+
+// TestTurnCapEndsInDraw: una partida bot contra bot que llega al límite de
+// turnos sin ganador termina ahí, en empate
+func TestTurnCapEndsInDraw(t *testing.T) {
+	g := NewGame("", 1, ModeBotVsBot, DifficultyEasy)
+	g.MCTS().Iterations = 50
+	g.MCTS().MaxDepth = 2
+	g.MCTS().RolloutPolicy = nil
+	g.SetMaxTurns(4)
+	g.Run()
+
+	if g.Turn() != 4 || len(g.history) != 4 {
+		t.Errorf("turnos = %d, jugadas = %d; want 4", g.Turn(), len(g.history))
+	}
+	if over, _ := g.rules.IsTerminal(g.board); over || g.winner() != ' ' {
+		t.Errorf("la partida no es empate: ganador %q", g.winner())
+	}
+	if !g.turnCapReached() {
+		t.Error("turnCapReached = false al terminar")
+	}
+}
+
+// TestOnMoveReportsEachMove: el gancho recibe al jugador, su movimiento y el
+// tablero ya actualizado, tanto en el turno del humano como en el del bot
+func TestOnMoveReportsEachMove(t *testing.T) {
+	g := newScriptedGame('B', "9 9\n")
+	var seen []MoveRecord
+	g.OnMove = func(player rune, move board.Move, b board.Board) {
+		if b[move[0].Row][move[0].Col] != player {
+			t.Errorf("%q %v: el tablero del gancho no tiene la jugada", player, move)
+		}
+		seen = append(seen, MoveRecord{Player: player, Move: move})
+	}
+	g.Run()
+
+	if len(seen) != 2 || len(g.history) != 2 {
+		t.Fatalf("gancho = %v, historial = %v", seen, g.history)
+	}
+	if seen[0] != (MoveRecord{Player: 'B', Move: board.Move{{Row: 9, Col: 9}, board.NoPosition}}) {
+		t.Errorf("jugada del humano: %v", seen[0])
+	}
+	if seen[1] != g.history[1] || seen[1].Player != 'W' {
+		t.Errorf("jugada del bot: %v, historial %v", seen[1], g.history[1])
+	}
+}
+
+// TestTruncatedInputEndsGame: si la entrada se corta a mitad de una jugada la
+// partida termina como abandonada en lugar de quedarse pidiendo jugadas
+func TestTruncatedInputEndsGame(t *testing.T) {
+	for _, input := range []string{"", "9", "9 9\n0 0 0"} {
+		g := newScriptedGame('B', input)
+		done := make(chan struct{})
+		go func() {
+			g.Run()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("entrada %q: la partida no terminó", input)
+		}
+		if !g.abandoned {
+			t.Errorf("entrada %q: la partida no quedó abandonada", input)
+		}
+	}
+}
+
+// TestFullBoardEndsInDraw: con el tablero lleno y sin línea, Run termina en
+// empate sin pedir jugadas (antes el bucle seguía pidiéndolas para siempre)
+func TestFullBoardEndsInDraw(t *testing.T) {
+	// Parejas BBWW desplazadas una fila: ninguna dirección junta más de dos
+	var b board.Board
+	for r := 0; r < board.BoardSize; r++ {
+		for c := 0; c < board.BoardSize; c++ {
+			b[r][c] = 'B'
+			if (r+c/2)%2 == 1 {
+				b[r][c] = 'W'
+			}
+		}
+	}
+	if !board.IsBoardFull(b) || board.GetWinner(b) != ' ' {
+		t.Fatal("la posición de prueba no es un tablero lleno sin ganador")
+	}
+
+	g := NewGame("", 1, ModeBotVsBot, DifficultyEasy)
+	g.SetPosition(b)
+	done := make(chan struct{})
+	go func() {
+		g.Run()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Run no terminó con el tablero lleno")
+	}
+	if len(g.history) != 0 {
+		t.Errorf("se jugó sobre el tablero lleno: %v", g.history)
+	}
+	if g.winner() != ' ' {
+		t.Errorf("winner = %q, want ' ' (empate)", g.winner())
+	}
+}
+
+// TestBotTurnPlaysForItsPiece: un turno del bot con un motor fijo (un libro
+// con la única jugada de la posición) coloca las piedras con las fichas del
+// bot, lo registra en el historial y pasa el turno al humano
+func TestBotTurnPlaysForItsPiece(t *testing.T) {
+	var b board.Board
+	b[9][9] = 'B'
+	reply := board.Move{{Row: 8, Col: 10}, {Row: 10, Col: 8}}
+
+	g := newScriptedGame('B', "")
+	g.SetPosition(b)
+	g.SetBook(map[uint64]board.Move{board.ZobristHash(b): reply})
+	g.SetMaxTurns(2)
+	g.Run()
+
+	if g.board[8][10] != 'W' || g.board[10][8] != 'W' || board.StoneCount(g.board) != 3 {
+		t.Errorf("el tablero no tiene la respuesta blanca %v:\n%s", reply, board.Serialize(g.board))
+	}
+	if len(g.history) != 1 || g.history[0] != (MoveRecord{Player: 'W', Move: reply}) {
+		t.Errorf("historial = %v", g.history)
+	}
+	if g.Turn() != 2 || g.currentPlayer != 'B' {
+		t.Errorf("turno = %d, juega %q; want 2 y 'B'", g.Turn(), g.currentPlayer)
+	}
+	if g.abandoned {
+		t.Error("se pidió jugada al humano tras el límite de turnos")
+	}
+}
@@ -0,0 +1,189 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"connect6/board"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MoveRecord es una jugada del historial: quién movió y qué piedras colocó
+type MoveRecord struct {
+	Player rune
+	Move   board.Move
+}
+
+// ExportRecord genera el registro de la partida en formato de texto
+// Formato (una línea por jugada, coordenadas 0-18):
+//
+//	B 9 9          <- apertura de una sola piedra: color fila columna
+//	W 8 7 10 10    <- color fila1 columna1 fila2 columna2
+//	RESULT B       <- B / W (ganador, también por rendición), draw (tablero lleno) o * (sin terminar)
+//
+// Las líneas vacías y las que empiezan con '#' se ignoran al importar
+func (g *Game) ExportRecord() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("# Connect6\n")
+	for _, rec := range g.history {
+		m := rec.Move
+		if m[1] == board.NoPosition {
+			fmt.Fprintf(&buf, "%c %d %d\n", rec.Player, m[0].Row, m[0].Col)
+		} else {
+			fmt.Fprintf(&buf, "%c %d %d %d %d\n", rec.Player, m[0].Row, m[0].Col, m[1].Row, m[1].Col)
+		}
+	}
+	tag := resultTag(g.board)
+	if g.resigned != 0 {
+		tag = string(g.winner())
+	}
+	fmt.Fprintf(&buf, "RESULT %s\n", tag)
+	return buf.Bytes()
+}
+
+// resultTag devuelve la etiqueta de resultado para el tablero
+func resultTag(b board.Board) string {
+	switch winner := board.GetWinner(b); {
+	case winner != ' ':
+		return string(winner)
+	case board.IsBoardFull(b):
+		return "draw"
+	}
+	return "*"
+}
+
+// ImportRecord reconstruye una partida a partir de un registro de ExportRecord
+// Parámetros:
+//   - data: Registro en formato de texto
+//
+// Retorna:
+//   - Game con el tablero, el historial y el turno tras la última jugada
+//   - error si alguna línea está mal formada o alguna jugada es ilegal
+func ImportRecord(data []byte) (*Game, error) {
+	records, err := parseRecord(data)
+	if err != nil {
+		return nil, err
+	}
+
+	g := NewGame("negras", 4, ModeHumanVsBot, DifficultyHard)
+	if err := g.applyRecords(records); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// LoadMoves reemplaza la partida por las jugadas de 'data' aplicadas en orden
+// desde el tablero vacío, validando cada una; después se sigue jugando (humano
+// o bot) desde la posición resultante. Sirve para reproducir partidas reportadas
+// Parámetros:
+//   - data: Una jugada por línea, en el formato de ExportRecord; el color se
+//     puede omitir ("9 9", "8 7 10 10") y entonces se alternan empezando por negras
+//
+// Retorna: error si alguna línea está mal formada o alguna jugada es ilegal;
+// en ese caso la partida no cambia
+func (g *Game) LoadMoves(data []byte) error {
+	records, err := parseRecord(data)
+	if err != nil {
+		return err
+	}
+	return g.applyRecords(records)
+}
+
+// applyRecords reproduce 'records' y, si todas son legales, deja la partida
+// (tablero, historial, turno y jugador por mover) tras la última
+func (g *Game) applyRecords(records []MoveRecord) error {
+	final, err := replayRecords(records, func(board.Board, MoveRecord) {})
+	if err != nil {
+		return err
+	}
+	g.SetPosition(final)
+	g.history = append(g.history, records...)
+	if len(records) > 0 {
+		g.currentPlayer = board.SwitchPlayer(records[len(records)-1].Player)
+	}
+	return nil
+}
+
+// Replay reproduce un registro jugada por jugada
+// Parámetros:
+//   - record: Registro en el formato de ExportRecord
+//   - step: Se invoca tras aplicar cada jugada con el tablero resultante
+//
+// Retorna: error si el registro está mal formado o una jugada es ilegal
+// para la posición (las jugadas previas ya se habrán notificado)
+func Replay(record []byte, step func(b board.Board, move board.Move)) error {
+	records, err := parseRecord(record)
+	if err != nil {
+		return err
+	}
+	_, err = replayRecords(records, func(b board.Board, rec MoveRecord) {
+		step(b, rec.Move)
+	})
+	return err
+}
+
+// replayRecords aplica las jugadas sobre un tablero vacío validando cada una
+// Retorna: El tablero final, o error en la primera jugada ilegal
+func replayRecords(records []MoveRecord, step func(b board.Board, rec MoveRecord)) (board.Board, error) {
+	var b board.Board
+	for i, rec := range records {
+		if board.GetWinner(b) != ' ' {
+			return b, fmt.Errorf("jugada %d: la partida ya había terminado", i+1)
+		}
+		if i > 0 && rec.Player == records[i-1].Player {
+			return b, fmt.Errorf("jugada %d: %c mueve dos veces seguidas", i+1, rec.Player)
+		}
+		if !board.IsValidMove(b, rec.Move[0], rec.Move[1]) {
+			return b, fmt.Errorf("jugada %d: movimiento ilegal %v", i+1, rec.Move)
+		}
+		board.ApplyMove(&b, rec.Move, rec.Player)
+		step(b, rec)
+	}
+	return b, nil
+}
+
+// parseRecord convierte el texto del registro en la lista de jugadas
+// La línea RESULT es informativa: el resultado se recalcula del tablero
+func parseRecord(data []byte) ([]MoveRecord, error) {
+	var records []MoveRecord
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "RESULT") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if _, err := strconv.Atoi(fields[0]); err == nil {
+			// Sin color: alterna con la jugada anterior (negras abren)
+			player := "B"
+			if len(records) > 0 {
+				player = string(board.SwitchPlayer(records[len(records)-1].Player))
+			}
+			fields = append([]string{player}, fields...)
+		}
+		if fields[0] != "B" && fields[0] != "W" {
+			return nil, fmt.Errorf("línea %d: color inválido %q", lineNo, fields[0])
+		}
+		if len(fields) != 3 && len(fields) != 5 {
+			return nil, fmt.Errorf("línea %d: se esperaban 2 o 4 coordenadas", lineNo)
+		}
+
+		coords := make([]int, len(fields)-1)
+		for i, f := range fields[1:] {
+			n, err := strconv.Atoi(f)
+			if err != nil {
+				return nil, fmt.Errorf("línea %d: coordenada inválida %q", lineNo, f)
+			}
+			coords[i] = n
+		}
+
+		move := board.Move{{Row: coords[0], Col: coords[1]}, board.NoPosition}
+		if len(coords) == 4 {
+			move[1] = board.Position{Row: coords[2], Col: coords[3]}
+		}
+		records = append(records, MoveRecord{Player: rune(fields[0][0]), Move: move})
+	}
+	return records, scanner.Err()
+}
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connect6/board"
+)
+
+// TestLoadMovesFromFile: un archivo con una jugada por línea (sin color) deja el
+// tablero esperado, el historial y el turno del siguiente jugador; una jugada
+// ilegal se rechaza sin cambiar la partida
+func TestLoadMovesFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jugadas.txt")
+	if err := os.WriteFile(path, []byte("9 9\n8 8 10 10\n9 8 9 10\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := NewGame("", 1, ModeBotVsBot, DifficultyEasy)
+	if err := g.LoadMoves(data); err != nil {
+		t.Fatal(err)
+	}
+	var want board.Board
+	want[9][9] = 'B'
+	want[8][8], want[10][10] = 'W', 'W'
+	want[9][8], want[9][10] = 'B', 'B'
+	if g.board != want {
+		t.Errorf("tablero tras las jugadas:\n%s\nwant:\n%s", board.Serialize(g.board), board.Serialize(want))
+	}
+	if len(g.history) != 3 || g.Turn() != 3 || g.currentPlayer != 'W' {
+		t.Errorf("historial %d, turno %d, mueve %q; want 3, 3, 'W'", len(g.history), g.Turn(), g.currentPlayer)
+	}
+
+	if err := g.LoadMoves([]byte("9 9\n9 9 1 1\n")); err == nil {
+		t.Error("una jugada sobre una casilla ocupada no dio error")
+	}
+	if g.board != want || len(g.history) != 3 {
+		t.Error("la carga fallida cambió la partida")
+	}
+}
+
+// TestReplayStepsThroughRecord: Replay aplica cada jugada de un registro en
+// orden, avisa con el tablero tras cada una y termina en la posición final;
+// una jugada ilegal corta la reproducción con error tras las anteriores
+func TestReplayStepsThroughRecord(t *testing.T) {
+	record := []byte("# Connect6\nB 9 9\nW 8 8 10 10\nB 9 8 9 10\nW 7 7 11 11\nRESULT *\n")
+	var want board.Board
+	want[9][9] = 'B'
+	want[8][8], want[10][10] = 'W', 'W'
+	want[9][8], want[9][10] = 'B', 'B'
+	want[7][7], want[11][11] = 'W', 'W'
+
+	var moves []board.Move
+	var stones []int
+	var final board.Board
+	err := Replay(record, func(b board.Board, move board.Move) {
+		moves = append(moves, move)
+		stones = append(stones, board.StoneCount(b))
+		final = b
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if final != want {
+		t.Errorf("tablero final:\n%s\nwant:\n%s", board.Serialize(final), board.Serialize(want))
+	}
+	if len(moves) != 4 || moves[0] != (board.Move{{Row: 9, Col: 9}, board.NoPosition}) || moves[3] != (board.Move{{Row: 7, Col: 7}, {Row: 11, Col: 11}}) {
+		t.Errorf("jugadas notificadas: %v", moves)
+	}
+	for i, n := range []int{1, 3, 5, 7} {
+		if i < len(stones) && stones[i] != n {
+			t.Errorf("paso %d: %d piedras, want %d", i+1, stones[i], n)
+		}
+	}
+
+	steps := 0
+	illegal := []byte("B 9 9\nW 8 8 10 10\nB 9 9 1 1\nW 0 0 0 1\n")
+	if err := Replay(illegal, func(board.Board, board.Move) { steps++ }); err == nil {
+		t.Error("la jugada sobre una casilla ocupada no dio error")
+	}
+	if steps != 2 {
+		t.Errorf("se notificaron %d jugadas antes del error, want 2", steps)
+	}
+}
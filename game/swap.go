@@ -0,0 +1,70 @@
+package game
+
+import (
+	"connect6/board"
+	"connect6/ui"
+	"fmt"
+)
+
+// swapRadius es la distancia (Chebyshev) al centro dentro de la cual el bot
+// considera fuerte la piedra de apertura y decide intercambiar colores
+const swapRadius = 3
+
+// SetSwap activa la regla de intercambio de colores tras la piedra de apertura
+//
+// Secuencia (solo en modo ModeHumanVsBot):
+//  1. Quien inicia coloca la piedra única de apertura
+//  2. Antes de responder, el segundo jugador decide si intercambia colores:
+//     el humano responde al prompt (s/n); el bot acepta si la piedra está
+//     a distancia swapRadius o menos del centro
+//  3. Si hay intercambio, el humano y el bot se cambian las fichas: el que
+//     iba a responder pasa a ser dueño de la piedra de apertura y el rival
+//     juega la primera jugada de dos piedras
+//  4. La partida continúa normalmente; la oferta no se repite
+func (g *Game) SetSwap(on bool) {
+	g.swap = on
+}
+
+// swapDue indica si la jugada recién aplicada fue la apertura: el tablero
+// tiene una sola piedra. No se mira el historial, que SetPosition vacía y
+// volvería a tener un solo movimiento en mitad de una partida cargada
+func (g *Game) swapDue() bool {
+	return board.StoneCount(g.board) == 1
+}
+
+// offerSwap ejecuta el paso 2 de la secuencia de SetSwap; se llama una sola vez,
+// justo después de la jugada de apertura, con g.currentPlayer ya en el segundo jugador
+func (g *Game) offerSwap() {
+	if !g.swap || g.mode != ModeHumanVsBot {
+		return
+	}
+
+	var swap bool
+	if g.isBot(g.currentPlayer) {
+		swap = botWantsSwap(g.history[len(g.history)-1].Move[0])
+	} else {
+		swap = g.console.AskSwap(g.currentPlayer)
+	}
+	if !swap {
+		fmt.Println(ui.T("swap.no"))
+		return
+	}
+
+	g.humanPiece, g.botPiece = g.botPiece, g.humanPiece
+	fmt.Println(ui.Tf("swap.yes", ui.PieceName(g.humanPiece)))
+}
+
+// botWantsSwap decide si el bot se queda con la piedra de apertura 'p'
+// Retorna: true si 'p' está cerca del centro del tablero
+func botWantsSwap(p board.Position) bool {
+	center := board.BoardSize / 2
+	return abs(p.Row-center) <= swapRadius && abs(p.Col-center) <= swapRadius
+}
+
+// abs retorna el valor absoluto de un entero
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
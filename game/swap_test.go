@@ -0,0 +1,46 @@
+package game
+
+import (
+	"testing"
+
+	"connect6/board"
+)
+
+// TestSwapOnlyAfterOpening ofrece el intercambio tras la piedra de apertura,
+// pero no tras la primera jugada de una partida cargada a mitad de juego
+func TestSwapOnlyAfterOpening(t *testing.T) {
+	p := func(r, c int) board.Position { return board.Position{Row: r, Col: c} }
+	center := board.BoardSize / 2
+
+	g := NewGame("negras", 1, ModeHumanVsBot, DifficultyEasy)
+	g.SetSwap(true)
+	if err := g.applyMove(board.Move{p(center, center), board.NoPosition}, 'B'); err != nil {
+		t.Fatal(err)
+	}
+	g.currentPlayer = 'W'
+	if !g.swapDue() {
+		t.Fatal("swapDue = false tras la apertura")
+	}
+	g.offerSwap()
+	if g.humanPiece != 'W' || g.botPiece != 'B' {
+		t.Errorf("el bot no se quedó con la apertura central: humano=%q bot=%q", g.humanPiece, g.botPiece)
+	}
+
+	// Posición cargada: apertura y una respuesta; le toca a las negras
+	var b board.Board
+	b[center][center] = 'B'
+	b[center][center+1] = 'W'
+	b[center+1][center] = 'W'
+	g = NewGame("negras", 1, ModeHumanVsBot, DifficultyEasy)
+	g.SetSwap(true)
+	g.SetPosition(b)
+	if err := g.applyMove(board.Move{p(0, 0), p(0, 1)}, 'B'); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.history) != 1 {
+		t.Fatalf("historial = %d movimientos, want 1", len(g.history))
+	}
+	if g.swapDue() {
+		t.Error("swapDue = true en mitad de una partida cargada")
+	}
+}
@@ -1,34 +1,326 @@
 package main
 
 import (
+	"connect6/board"
+	"connect6/config"
 	"connect6/game"
+	"connect6/mcts"
+	"connect6/protocol"
+	"connect6/server"
+	"connect6/tournament"
+	"connect6/ui"
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
+	"os"
+	"time"
 )
 
 // Variables globales, o inline en main()
 var (
 	fichasFlag string
 	tpjFlag    int
+	modeFlag   string
+	replayFlag string
+	pvFlag     bool
+	colorFlag  bool
+	serveFlag  string
+	gtpFlag    bool
+	levelFlag  string
+	swapFlag   bool
+	bookFlag   string
+	baseFlag   int
+	clearFlag  bool
+	langFlag   string
+	posFlag    string
+	analyzeN   int
+	maxTurns   int
+	selfPlayN  int
+	outFlag    string
+	movesFlag  string
+	engineFlag string
+	configFlag string
+
+	// settings son los ajustes de -config ya combinados con las banderas
+	settings config.Settings
 )
 
 func init() {
 	// Define tus banderas y valores por defecto:
-	flag.StringVar(&fichasFlag, "fichas", "negras", "Indica si juegas con blancas o negras")
+	flag.StringVar(&fichasFlag, "fichas", "blancas", "Indica si juegas con blancas o negras (las negras abren; sin la bandera se pregunta en hvb)")
 	flag.IntVar(&tpjFlag, "tpj", 4, "Tiempo máximo (segundos) para la jugada de la IA")
+	flag.StringVar(&modeFlag, "mode", game.ModeHumanVsBot, "Modo de juego: hvb (humano vs bot), bvb (bot vs bot) o hvh (humano vs humano)")
+	flag.StringVar(&engineFlag, "engine", game.EngineMCTS, "Motor del bot: mcts o minimax (alfa-beta determinista)")
+	flag.StringVar(&levelFlag, "difficulty", game.DifficultyHard, "Dificultad del bot: easy, medium o hard")
+	flag.IntVar(&maxTurns, "maxturns", 0, "Turnos tras los que la partida termina en empate (0 = sin límite)")
+	flag.BoolVar(&swapFlag, "swap", false, "Tras la piedra de apertura, el segundo jugador puede intercambiar colores (solo hvb)")
+	flag.BoolVar(&pvFlag, "pv", false, "Muestra la variante principal y estadísticas tras cada jugada del bot")
+	flag.IntVar(&baseFlag, "base", 0, "Numeración de filas y columnas en pantalla: 0 (0-18) o 1 (1-19)")
+	flag.StringVar(&langFlag, "lang", "es", "Idioma de los mensajes: es o en")
+	flag.BoolVar(&colorFlag, "color", false, "Muestra el tablero con colores ANSI (solo en terminal)")
+	flag.BoolVar(&clearFlag, "clear", false, "Borra la pantalla antes de dibujar el tablero en cada turno (solo en terminal)")
+	flag.StringVar(&serveFlag, "serve", "", "Dirección (p.ej. :8080) para servir la API HTTP en lugar de jugar")
+	flag.BoolVar(&gtpFlag, "gtp", false, "Habla un protocolo de texto estilo GTP por stdin/stdout")
+	flag.StringVar(&bookFlag, "book", "", "Archivo de libro de aperturas que el bot consulta antes de buscar")
+	flag.StringVar(&posFlag, "position", "", "Tablero serializado (361 caracteres '.', 'B' o 'W', fila por fila) desde el que empezar la partida")
+	flag.IntVar(&analyzeN, "analyze", 0, "Analiza la posición (vacía o la de -position) y muestra los N mejores movimientos en lugar de jugar")
+	flag.IntVar(&selfPlayN, "selfplay", 0, "Juega N partidas del bot contra sí mismo y escribe datos de entrenamiento en -out")
+	flag.StringVar(&outFlag, "out", "selfplay.jsonl", "Archivo JSONL de salida de -selfplay")
+	flag.StringVar(&movesFlag, "moves", "", "Archivo de jugadas (una por línea, formato de registro) a aplicar desde el tablero vacío antes de seguir jugando")
+	flag.StringVar(&configFlag, "config", "", "Archivo de ajustes (clave=valor o JSON): iterations, tpj, exploration, difficulty, lang, color; las banderas explícitas tienen prioridad")
+	flag.StringVar(&replayFlag, "replay", "", "Archivo de registro de partida a reproducir paso a paso")
 }
 
 func main() {
 	// Parseamos los flags:
 	flag.Parse()
+	// Idioma de -lang ya para los errores de -config; se valida más abajo
+	ui.SetLang(langFlag)
+	if configFlag != "" {
+		var err error
+		if settings, err = config.Load(configFlag); err != nil {
+			fmt.Println(ui.Tf("main.config", err))
+			os.Exit(1)
+		}
+		if err := validateSettings(); err != nil {
+			fmt.Println(ui.Tf("main.config", err))
+			os.Exit(1)
+		}
+		applySettings()
+	}
+	ui.SetColor(colorFlag)
+	ui.SetClear(clearFlag)
+	if !ui.SetLang(langFlag) {
+		fmt.Println(ui.Tf("main.lang", langFlag))
+		os.Exit(1)
+	}
+	if baseFlag != 0 && baseFlag != 1 {
+		fmt.Println(ui.T("main.base"))
+		os.Exit(1)
+	}
+	ui.CoordinateBase = baseFlag
+
+	if gtpFlag {
+		engine := newEngine()
+		if err := protocol.NewSession(engine).Run(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, ui.Tf("error", err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	if serveFlag != "" {
+		engine := newEngine()
+		fmt.Println(ui.Tf("main.serving", serveFlag))
+		if err := http.ListenAndServe(serveFlag, server.NewHandler(engine)); err != nil {
+			fmt.Println(ui.Tf("error", err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	if selfPlayN > 0 {
+		runSelfPlay(selfPlayN, outFlag)
+		return
+	}
+
+	if analyzeN > 0 {
+		runAnalyze(analyzeN)
+		return
+	}
+
+	if replayFlag != "" {
+		runReplay(replayFlag)
+		return
+	}
 
 	// Muestra qué se parseó (opcional)
-	fmt.Println("Opción fichas:", fichasFlag)
-	fmt.Println("Opción tpj:", tpjFlag)
+	fmt.Println(ui.Tf("main.option", "fichas", fichasFlag))
+	fmt.Println(ui.Tf("main.option", "tpj", tpjFlag))
+	fmt.Println(ui.Tf("main.option", "mode", modeFlag))
+	fmt.Println(ui.Tf("main.option", "difficulty", levelFlag))
+
+	switch modeFlag {
+	case game.ModeHumanVsBot, game.ModeBotVsBot, game.ModeHumanVsHuman:
+	default:
+		fmt.Println(ui.Tf("main.mode", modeFlag))
+		os.Exit(1)
+	}
+	switch engineFlag {
+	case game.EngineMCTS, game.EngineMinimax:
+	default:
+		fmt.Println(ui.Tf("main.engine", engineFlag))
+		os.Exit(1)
+	}
+	if !game.ValidDifficulty(levelFlag) {
+		fmt.Println(ui.Tf("main.difficulty", levelFlag))
+		os.Exit(1)
+	}
 
 	// Crea el juego y pásale esos parámetros
 	// Para que tu agente sepa si inicia con negras o blancas y/o
 	// para que la IA use 'tpjFlag' segundos por turno
-	g := game.NewGame(fichasFlag, tpjFlag)
+	g := game.NewGame(fichasFlag, tpjFlag, modeFlag, levelFlag)
+	settings.ApplyEngine(g.MCTS())
+	// Sin -fichas explícito, el humano elige sus fichas en el menú
+	if modeFlag == game.ModeHumanVsBot && !flagSet("fichas") {
+		g.SetHumanPiece(ui.ShowGameMenu())
+	}
+	if posFlag != "" {
+		g.SetPosition(mustLoadPosition(posFlag))
+	}
+	if movesFlag != "" {
+		if posFlag != "" {
+			fmt.Println(ui.T("main.moves.combine"))
+			os.Exit(1)
+		}
+		data, err := os.ReadFile(movesFlag)
+		if err != nil {
+			fmt.Println(ui.Tf("error", err))
+			os.Exit(1)
+		}
+		if err := g.LoadMoves(data); err != nil {
+			fmt.Println(ui.Tf("main.moves.error", err))
+			os.Exit(1)
+		}
+	}
+	g.SetEngine(engineFlag)
+	g.SetShowPV(pvFlag)
+	g.SetMaxTurns(maxTurns)
+	g.SetSwap(swapFlag)
+	if bookFlag != "" {
+		book, err := board.LoadOpeningBook(bookFlag)
+		if err != nil {
+			fmt.Println(ui.Tf("main.book.error", err))
+			os.Exit(1)
+		}
+		g.SetBook(book)
+	}
 	g.Run()
 }
+
+// applySettings pasa a las banderas los ajustes de -config que no se dieron
+// explícitamente en la línea de comandos (esas tienen prioridad)
+func applySettings() {
+	if flagSet("tpj") {
+		settings.TimeLimit = tpjFlag
+	} else if settings.Has("tpj") {
+		tpjFlag = settings.TimeLimit
+	}
+	if settings.Has("difficulty") && !flagSet("difficulty") {
+		levelFlag = settings.Difficulty
+	}
+	if settings.Has("lang") && !flagSet("lang") {
+		langFlag = settings.Lang
+	}
+	if settings.Has("color") && !flagSet("color") {
+		colorFlag = settings.Color
+	}
+}
+
+// validateSettings comprueba los ajustes de -config que dependen del juego y de
+// la interfaz (config no los conoce)
+// Retorna: error si la dificultad o el idioma son desconocidos
+func validateSettings() error {
+	if settings.Has("difficulty") && !game.ValidDifficulty(settings.Difficulty) {
+		return errors.New(ui.Tf("main.config.diff", settings.Difficulty))
+	}
+	if settings.Has("lang") && !ui.HasLang(settings.Lang) {
+		return errors.New(ui.Tf("main.config.lang", settings.Lang))
+	}
+	return nil
+}
+
+// newEngine crea un motor MCTS con los parámetros de las banderas y de -config
+// Simula con la política heurística, como el bot de la partida
+func newEngine() *mcts.MCTS {
+	engine := mcts.NewMCTS(time.Now().UnixNano(), 100000, 30, tpjFlag)
+	engine.RolloutPolicy = engine.HeuristicRollout
+	settings.ApplyEngine(engine)
+	return engine
+}
+
+// flagSet indica si la bandera 'name' se pasó en la línea de comandos
+func flagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// runSelfPlay escribe en 'path' los datos de 'games' partidas de autojuego
+func runSelfPlay(games int, path string) {
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Println(ui.Tf("error", err))
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	engine := newEngine()
+	if err := tournament.SelfPlay(engine, games, f); err != nil {
+		fmt.Println(ui.Tf("error", err))
+		os.Exit(1)
+	}
+	fmt.Println(ui.Tf("main.selfplay.done", path))
+}
+
+// runAnalyze busca en la posición de -position (o el tablero vacío) para el jugador
+// al que le toca y muestra los 'n' mejores movimientos de la raíz
+func runAnalyze(n int) {
+	b := mustLoadPosition(posFlag)
+	player := board.GetCurrentPlayer(b)
+	engine := newEngine()
+	ui.PrintBoard(b)
+	ui.ShowAnalysis(player, engine.Analyze(b, player, n))
+}
+
+// loadPosition interpreta el valor de -position
+// Parámetros:
+//   - s: Tablero de board.Serialize, o "" para el tablero vacío
+//
+// Retorna: El tablero, o error si la cadena está mal formada o los conteos de
+// piedras no se pueden alcanzar jugando (board.ValidatePosition)
+func loadPosition(s string) (board.Board, error) {
+	if s == "" {
+		return board.Board{}, nil
+	}
+	return board.ParsePosition(s)
+}
+
+// mustLoadPosition es loadPosition terminando el programa con el error traducido
+func mustLoadPosition(s string) board.Board {
+	b, err := loadPosition(s)
+	if err != nil {
+		fmt.Println(ui.Tf("main.position", err))
+		os.Exit(1)
+	}
+	return b
+}
+
+// runReplay muestra cada posición de una partida guardada
+func runReplay(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Println(ui.Tf("error", err))
+		os.Exit(1)
+	}
+	var last board.Board
+	err = game.Replay(data, func(b board.Board, move board.Move) {
+		player := b[move[0].Row][move[0].Col]
+		ui.ShowMove(player, move)
+		ui.PrintBoardHighlighted(b, move, board.WinningLine(b, player))
+		last = b
+	})
+	if err != nil {
+		fmt.Println(ui.Tf("error", err))
+		os.Exit(1)
+	}
+	if over, winner := board.IsTerminal(last); over {
+		ui.ShowResult(winner)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"connect6/board"
+	"connect6/game"
+)
+
+// TestLoadPosition: un -position válido da ese tablero y la partida sigue con
+// el jugador al que le toca; los conteos imposibles y las cadenas mal formadas
+// se rechazan, y sin -position se empieza con el tablero vacío
+func TestLoadPosition(t *testing.T) {
+	var want board.Board
+	want[9][9] = 'B'
+	want[9][10], want[10][10] = 'W', 'W'
+	b, err := loadPosition(board.Serialize(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b != want {
+		t.Errorf("tablero cargado:\n%s\nwant:\n%s", board.Serialize(b), board.Serialize(want))
+	}
+	g := game.NewGame("", 1, game.ModeBotVsBot, game.DifficultyEasy)
+	g.SetPosition(b)
+	if g.Turn() != 2 || board.GetCurrentPlayer(b) != 'B' {
+		t.Errorf("turno %d, mueve %q; want 2 y 'B'", g.Turn(), board.GetCurrentPlayer(b))
+	}
+
+	if b, err := loadPosition(""); err != nil || b != (board.Board{}) {
+		t.Errorf("sin -position: %v, tablero con %d piedras", err, board.StoneCount(b))
+	}
+
+	// Dos negras y ninguna blanca no se pueden alcanzar (ValidatePosition)
+	var impossible board.Board
+	impossible[9][9], impossible[9][10] = 'B', 'B'
+	if board.ValidatePosition(impossible) == nil {
+		t.Fatal("ValidatePosition aceptó dos negras sin blancas")
+	}
+	if _, err := loadPosition(board.Serialize(impossible)); err == nil {
+		t.Error("se aceptó una posición con conteos imposibles")
+	}
+	for _, bad := range []string{"B", strings.Repeat(".", 360) + "x"} {
+		if _, err := loadPosition(bad); err == nil {
+			t.Errorf("se aceptó la posición mal formada %.10q...", bad)
+		}
+	}
+}
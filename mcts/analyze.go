@@ -0,0 +1,55 @@
+package mcts
+
+import (
+	"context"
+	"sort"
+
+	"connect6/board"
+)
+
+// Candidate resume un hijo de la raíz tras una búsqueda de análisis
+type Candidate struct {
+	Move    board.Move
+	Visits  int
+	WinRate float64 // victorias/visitas del hijo (0-1)
+	Eval    float64 // board.EvaluateBoard tras el movimiento, para quien mueve
+}
+
+// Analyze busca como Search y retorna los mejores movimientos de la raíz
+// Parámetros:
+// - state: Tablero actual
+// - currentPlayer: Jugador que debe mover
+// - n: Máximo de candidatos (<= 0 => todos los hijos explorados)
+// Retorna: Candidatos ordenados de más a menos visitas (orden estable: a igual
+// número de visitas se conserva el orden de expansión); nil si no hay hijos
+func (m *MCTS) Analyze(state board.Board, currentPlayer rune, n int) []Candidate {
+	ctx, cancel := m.withMoveTime(state)
+	defer cancel()
+	return m.AnalyzeContext(ctx, state, currentPlayer, n)
+}
+
+// AnalyzeContext es Analyze limitado por 'ctx' en lugar de MoveTime
+func (m *MCTS) AnalyzeContext(ctx context.Context, state board.Board, currentPlayer rune, n int) []Candidate {
+	root := m.searchTree(ctx, state, currentPlayer)
+
+	var candidates []Candidate
+	for _, e := range root.children {
+		child := e.node
+		if child.visits == 0 {
+			continue
+		}
+		candidates = append(candidates, Candidate{
+			Move:    e.move,
+			Visits:  child.visits,
+			WinRate: child.wins / float64(child.visits),
+			Eval:    m.evaluate(child.board, currentPlayer),
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Visits > candidates[j].Visits
+	})
+	if n > 0 && len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	return candidates
+}
@@ -0,0 +1,46 @@
+package mcts
+
+import (
+	"testing"
+
+	"connect6/board"
+)
+
+// TestAnalyzeSortedByVisits: los candidatos salen de más a menos visitas,
+// con tasas de victoria válidas, y 'n' limita la lista
+func TestAnalyzeSortedByVisits(t *testing.T) {
+	m := NewMCTS(1, 100, 2, 60)
+	m.RolloutPolicy = RandomRollout
+	all := m.Analyze(openingBoard(), 'B', 0)
+	if len(all) < 2 {
+		t.Fatalf("solo %d candidatos", len(all))
+	}
+	total := 0
+	for i, c := range all {
+		if i > 0 && c.Visits > all[i-1].Visits {
+			t.Errorf("candidato %d (%d visitas) supera al anterior (%d)", i, c.Visits, all[i-1].Visits)
+		}
+		if c.WinRate < 0 || c.WinRate > 1 {
+			t.Errorf("%v: tasa de victoria %v", c.Move, c.WinRate)
+		}
+		if want := board.EvaluateBoard(applied(openingBoard(), c.Move, 'B'), 'B'); c.Eval != want {
+			t.Errorf("%v: Eval %.0f, want %.0f", c.Move, c.Eval, want)
+		}
+		total += c.Visits
+	}
+	if total > 100 {
+		t.Errorf("%d visitas en los hijos con 100 simulaciones", total)
+	}
+
+	m = NewMCTS(1, 100, 2, 60)
+	m.RolloutPolicy = RandomRollout
+	if top := m.Analyze(openingBoard(), 'B', 3); len(top) != 3 || top[0] != all[0] {
+		t.Errorf("top 3 = %v, want los primeros de %v", top, all[:3])
+	}
+}
+
+// applied retorna una copia de 'b' con 'move' de 'player'
+func applied(b board.Board, move board.Move, player rune) board.Board {
+	board.ApplyMove(&b, move, player)
+	return b
+}
@@ -0,0 +1,51 @@
+package mcts
+
+import (
+	"sort"
+
+	"connect6/board"
+)
+
+// avoidBlunder es la última revisión del movimiento elegido en la raíz
+// Si 'move' deja al rival una victoria inmediata (board.FindWinningMove tras
+// jugarlo), se prefiere el hijo más visitado que no la deja y, si ninguno sirve,
+// el primer movimiento de bloqueo de las casillas críticas del rival
+// (board.FindCriticalBlocks) que tampoco la deja
+// Retorna: 'move' si es seguro o no hay alternativa segura (la partida está perdida)
+func (m *MCTS) avoidBlunder(root *Node, move board.Move) board.Move {
+	mover := board.SwitchPlayer(root.player)
+	rules := m.rules()
+	if !allowsWin(rules, root.board, move, mover) {
+		return move
+	}
+
+	children := append([]edge(nil), root.children...)
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].node.visits > children[j].node.visits
+	})
+	for _, e := range children {
+		if !allowsWin(rules, root.board, e.move, mover) {
+			return e.move
+		}
+	}
+
+	for _, block := range blockingMoves(rules, root.board, root.player, DefaultCriticalBlockLen, rules.GenerateSmartMoves(root.board)) {
+		if !allowsWin(rules, root.board, block, mover) {
+			return block
+		}
+	}
+	return move
+}
+
+// allowsWin indica si tras jugar 'move' el rival de 'mover' gana en su turno
+// Un movimiento que ya gana la partida nunca la permite; las victorias son las de 'rules'
+func allowsWin(rules board.Rules, b board.Board, move board.Move, mover rune) bool {
+	after := b
+	if !board.ApplyMove(&after, move, mover) {
+		return false
+	}
+	if rules.MoveWins(after, move, mover) {
+		return false
+	}
+	return rules.FindWinningMove(after, board.SwitchPlayer(mover)) != nil
+}
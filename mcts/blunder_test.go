@@ -0,0 +1,47 @@
+package mcts
+
+import (
+	"testing"
+
+	"connect6/board"
+)
+
+// TestAvoidBlunderPrefersSafeMove: si el hijo más visitado deja ganar al rival
+// se elige el siguiente que no lo deja; si ninguno sirve, un bloqueo
+func TestAvoidBlunderPrefersSafeMove(t *testing.T) {
+	p := func(r, c int) board.Position { return board.Position{Row: r, Col: c} }
+	var b board.Board
+	for c := 4; c <= 8; c++ {
+		b[9][c] = 'W' // cinco blancas que se completan en (9,9)
+	}
+	b[9][3] = 'B'
+	for c := 0; c < 4; c++ {
+		b[15][c*2] = 'B'
+	}
+
+	naive := board.Move{p(0, 0), p(0, 1)}
+	safe := board.Move{p(9, 9), p(5, 5)}
+	child := func(move board.Move, visits int) edge {
+		after := b
+		board.ApplyMove(&after, move, 'B')
+		node := NewNode(after, 'B')
+		node.visits = visits
+		return edge{move: move, node: node}
+	}
+
+	m := NewMCTS(1, 1, 1, 1)
+	root := NewNode(b, 'W')
+	root.children = []edge{child(naive, 100), child(safe, 10)}
+	if got := m.avoidBlunder(root, naive); got != safe {
+		t.Errorf("avoidBlunder(%v) = %v, want %v", naive, got, safe)
+	}
+	if got := m.avoidBlunder(root, safe); got != safe {
+		t.Errorf("un movimiento seguro cambió a %v", got)
+	}
+
+	root.children = root.children[:1]
+	got := m.avoidBlunder(root, naive)
+	if got[0] != p(9, 9) && got[1] != p(9, 9) {
+		t.Errorf("sin hijos seguros se eligió %v, que no bloquea (9,9)", got)
+	}
+}
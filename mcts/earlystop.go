@@ -0,0 +1,25 @@
+package mcts
+
+// DefaultEarlyStopMin es el mínimo de simulaciones que usa NewMCTS (ver EarlyStopMin)
+const DefaultEarlyStopMin = 1000
+
+// confident indica si la búsqueda puede terminar antes de agotar su presupuesto:
+// tras al menos EarlyStopMin simulaciones, el hijo más visitado de la raíz
+// acumula más de EarlyStopShare de las visitas de todos los hijos
+// Parámetros:
+// - root: Raíz de la búsqueda
+// - done: Simulaciones hechas en esta búsqueda
+// Retorna: true si conviene parar; siempre false con EarlyStopShare = 0
+func (m *MCTS) confident(root *Node, done int) bool {
+	if m.EarlyStopShare <= 0 || done < m.EarlyStopMin {
+		return false
+	}
+	total, best := 0, 0
+	for _, e := range root.children {
+		total += e.node.visits
+		if e.node.visits > best {
+			best = e.node.visits
+		}
+	}
+	return total > 0 && float64(best) > m.EarlyStopShare*float64(total)
+}
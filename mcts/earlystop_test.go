@@ -0,0 +1,49 @@
+package mcts
+
+import (
+	"testing"
+
+	"connect6/board"
+)
+
+// TestEarlyStopInForcedPosition: ante dos cincos blancos solo un movimiento
+// bloquea ambos, así que la búsqueda para en cuanto reúne EarlyStopMin
+// simulaciones; sin EarlyStopShare gasta todo el presupuesto
+func TestEarlyStopInForcedPosition(t *testing.T) {
+	var b board.Board
+	for i := 4; i <= 8; i++ {
+		b[9][i] = 'W'  // cinco blancas que se completan en (9,9)
+		b[i][10] = 'W' // y otras cinco que se completan en (9,10)
+	}
+	b[9][3] = 'B'
+	b[3][10] = 'B'
+	for c := 0; c < 8; c++ {
+		b[0][c*2] = 'B'
+	}
+
+	search := func(share float64) SearchInfo {
+		m := NewMCTS(1, 400, 2, 60)
+		m.RolloutPolicy = RandomRollout
+		m.Exploration = 0.2 // poca exploración: los bloqueos parciales pierden visitas pronto
+		m.EarlyStopShare = share
+		m.EarlyStopMin = 40
+		_, info, ok := m.SearchWithInfo(b, 'B')
+		if !ok {
+			t.Fatal("la búsqueda no retornó movimiento")
+		}
+		return info
+	}
+
+	early := search(0.9)
+	after := b
+	board.ApplyMove(&after, early.Move, 'B')
+	if board.FindWinningMove(after, 'W') != nil {
+		t.Errorf("el movimiento %v no bloquea las dos amenazas", early.Move)
+	}
+	if early.Simulations >= 400 {
+		t.Errorf("con EarlyStopShare 0.9 se hicieron %d simulaciones", early.Simulations)
+	}
+	if full := search(0); full.Simulations < 400 {
+		t.Errorf("sin EarlyStopShare solo se hicieron %d simulaciones", full.Simulations)
+	}
+}
@@ -0,0 +1,68 @@
+package mcts
+
+import (
+	"math/rand"
+	"testing"
+
+	"connect6/board"
+)
+
+// scatteredBoards genera 'n' tableros con piedras al azar (unas 30 cada uno)
+func scatteredBoards(rng *rand.Rand, n int) []board.Board {
+	boards := make([]board.Board, n)
+	for i := range boards {
+		for s := 0; s < 30; s++ {
+			r, c := rng.Intn(board.BoardSize), rng.Intn(board.BoardSize)
+			boards[i][r][c] = 'B'
+			if s%2 == 1 {
+				boards[i][r][c] = 'W'
+			}
+		}
+	}
+	return boards
+}
+
+// TestCachedEvaluationMatchesFresh: la evaluación de la caché coincide con
+// EvaluateBoard para ambos jugadores, antes y después de guardarla, y la caché
+// no pasa de EvalCacheSize
+func TestCachedEvaluationMatchesFresh(t *testing.T) {
+	m := NewMCTS(1, 1, 1, 1)
+	m.EvalCacheSize = 8
+	boards := scatteredBoards(rand.New(rand.NewSource(62)), 20)
+	for round := 0; round < 2; round++ {
+		for i, b := range boards {
+			for _, player := range []rune{'B', 'W'} {
+				if got, want := m.evaluate(b, player), board.EvaluateBoard(b, player); got != want {
+					t.Fatalf("vuelta %d, tablero %d, %q: caché %.0f, EvaluateBoard %.0f", round, i, player, got, want)
+				}
+			}
+		}
+		if n := len(m.cache.entries); n == 0 || n > m.EvalCacheSize {
+			t.Errorf("vuelta %d: %d entradas con EvalCacheSize %d", round, n, m.EvalCacheSize)
+		}
+	}
+
+	m.ClearCache()
+	if m.cache != nil {
+		t.Error("ClearCache no vació la caché")
+	}
+}
+
+// BenchmarkEvaluate compara EvaluateBoard con la caché sobre posiciones que se
+// repiten, como las del final de las simulaciones
+func BenchmarkEvaluate(b *testing.B) {
+	boards := scatteredBoards(rand.New(rand.NewSource(62)), 64)
+	for _, size := range []int{0, DefaultEvalCacheSize} {
+		name := "fresh"
+		if size > 0 {
+			name = "cached"
+		}
+		b.Run(name, func(b *testing.B) {
+			m := NewMCTS(1, 1, 1, 1)
+			m.EvalCacheSize = size
+			for i := 0; i < b.N; i++ {
+				m.evaluate(boards[i%len(boards)], 'B')
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package mcts
+
+import (
+	"connect6/board"
+)
+
+// SearchInfo resume lo que "pensó" el motor en una búsqueda
+type SearchInfo struct {
+	Move        board.Move   // movimiento elegido
+	Visits      int          // visitas del hijo elegido
+	WinRate     float64      // victorias/visitas del hijo elegido (0-1)
+	Simulations int          // simulaciones totales en la raíz
+	PV          []board.Move // variante principal: camino de hijos más visitados
+}
+
+// SearchWithInfo busca como Search y además retorna las estadísticas de la búsqueda
+// Parámetros:
+// - state: Tablero actual
+// - currentPlayer: Jugador para el que se busca el movimiento
+// Retorna: Mejor movimiento y su SearchInfo; false si no hay movimientos legales
+func (m *MCTS) SearchWithInfo(state board.Board, currentPlayer rune) (board.Move, SearchInfo, bool) {
+	ctx, cancel := m.withMoveTime(state)
+	defer cancel()
+
+	if move, ok := m.bookMove(state); ok {
+		return move, SearchInfo{Move: move, PV: []board.Move{move}}, true
+	}
+
+	root := m.searchTree(ctx, state, currentPlayer)
+	move, ok := m.getBestMove(root)
+	if !ok {
+		return move, SearchInfo{}, false
+	}
+	return move, buildSearchInfo(root, move), true
+}
+
+// buildSearchInfo recoge las estadísticas del hijo elegido y la variante principal
+func buildSearchInfo(root *Node, move board.Move) SearchInfo {
+	info := SearchInfo{Move: move, Simulations: root.visits}
+	node := childByMove(root, move)
+	if node != nil && node.visits > 0 {
+		info.Visits = node.visits
+		info.WinRate = node.wins / float64(node.visits)
+	}
+
+	// La PV empieza por el movimiento elegido y sigue por los más visitados;
+	// cada movimiento sale de la arista recorrida (con transposiciones el
+	// mismo nodo puede alcanzarse con otro movimiento desde otro padre)
+	info.PV = append(info.PV, move)
+	for node != nil {
+		e := mostVisitedChild(node)
+		if e == nil {
+			break
+		}
+		info.PV = append(info.PV, e.move)
+		node = e.node
+	}
+	return info
+}
+
+// childByMove retorna el hijo de 'node' alcanzado con 'move', o nil
+func childByMove(node *Node, move board.Move) *Node {
+	for _, e := range node.children {
+		if sameMove(e.move, move) {
+			return e.node
+		}
+	}
+	return nil
+}
+
+// mostVisitedChild retorna la arista al hijo con más visitas (nil si no tiene visitados)
+func mostVisitedChild(node *Node) *edge {
+	var best *edge
+	for i := range node.children {
+		e := &node.children[i]
+		if e.node.visits > 0 && (best == nil || e.node.visits > best.node.visits) {
+			best = e
+		}
+	}
+	return best
+}
@@ -1,6 +1,7 @@
 package mcts
 
 import (
+	"context"
 	"math"
 	"math/rand"
 	"time"
@@ -8,183 +9,572 @@ import (
 	"connect6/board"
 )
 
+// DefaultExploration es la constante de exploración UCB clásica (sqrt(2))
+const DefaultExploration = math.Sqrt2
+
+// DefaultCriticalBlockLen es el umbral de bloqueo que usa NewMCTS (ver CriticalBlockLen)
+const DefaultCriticalBlockLen = 4
+
+// DefaultVirtualLoss es la pérdida virtual que usa NewMCTS (ver VirtualLoss)
+const DefaultVirtualLoss = 1
+
+// DefaultLowConfidence es el umbral de confianza que usa NewMCTS (ver LowConfidence)
+const DefaultLowConfidence = 0.4
+
+// rolloutEvalScale es la escala de la logística que convierte la evaluación
+// final de una simulación sin ganador en un valor (ver squash). Vale lo que un
+// cuatro abierto en DefaultWeights: un tres abierto da ~0.56, un cuatro abierto
+// ~0.73 y un cinco abierto ~0.97. Medido desde la apertura con simulaciones al
+// azar, la mediana de |EvaluateBoard| al cortar es ~3.000 con MaxDepth 4,
+// ~19.000 con 10 y ~135.000 con 30: con una escala de 2.000 casi todo saturaba
+const rolloutEvalScale = 30000.0
+
+// DefaultEndgameCells es el umbral de casillas vacías que usa NewMCTS (ver EndgameCells)
+const DefaultEndgameCells = 10
+
+// maxBlockMoves limita los movimientos de bloqueo que se generan para la raíz
+const maxBlockMoves = 150
+
 type MCTS struct {
 	Iterations  int     // Límite máximo de simulaciones
-	Exploration float64 // Constante de exploración
+	Exploration float64 // Constante de exploración (0 => explotación pura)
 	MaxDepth    int     // Profundidad máxima de la simulación (rollout)
 	TimeLimit   int     // Límite en segundos
+
+	// AdaptiveTime reparte el tiempo según la fase de la partida en lugar de usar
+	// siempre TimeLimit (ver MoveTime)
+	AdaptiveTime bool
+
+	// GameClock es el tiempo que le queda al motor para toda la partida; cada
+	// búsqueda lo descuenta y MoveTime no asigna más de su parte. 0 => sin reloj
+	GameClock time.Duration
+
+	UseTranspositions bool // Comparte nodos entre posiciones repetidas (desactivado por defecto)
+
+	// RolloutPolicy elige cada jugada de la simulación; nil elige uniformemente
+	// al azar entre los movimientos que genera Rules. Ver RandomRolloutFor,
+	// GreedyRolloutFor y HeuristicRollout. 'rng' es el generador de la búsqueda
+	// (o del worker) que la invoca. Retorna Move{} si no propone ningún movimiento.
+	RolloutPolicy func(b board.Board, player rune, rng *rand.Rand) board.Move
+
+	ReuseTree bool // Conserva el subárbol entre búsquedas (ver Advance)
+
+	// RootNoise es el peso (0-1) del ruido de Dirichlet mezclado en la
+	// selección de los hijos de la raíz; 0 lo desactiva
+	RootNoise float64
+
+	// Temperature > 0 elige el movimiento final al azar con probabilidad
+	// proporcional a visits^(1/Temperature); 0 elige siempre el más visitado
+	Temperature float64
+
+	Workers int // Árboles independientes en paralelo (0 o 1 => un solo hilo)
+
+	// SharedTree hace que los Workers compartan un único árbol (tree
+	// parallelization) en lugar de combinar árboles independientes al final
+	SharedTree bool
+
+	// VirtualLoss son las visitas sin victoria que se suman temporalmente a cada
+	// nodo del camino elegido por un worker, hasta su backpropagate, para que los
+	// demás workers exploren otros caminos; solo tiene efecto con SharedTree
+	VirtualLoss int
+
+	// Progressive widening: un nodo solo añade un hijo nuevo mientras tenga menos
+	// de WideningC * visits^WideningAlpha hijos; WideningC = 0 lo desactiva
+	WideningC     float64
+	WideningAlpha float64
+
+	// CriticalBlockLen restringe la raíz a movimientos que ocupan alguna casilla de
+	// board.FindCriticalBlocksN(raíz, rival, CriticalBlockLen); 0 lo desactiva
+	CriticalBlockLen int
+
+	// LowConfidence: si el hijo más visitado gana menos de esta proporción de sus
+	// simulaciones y no corta el tres abierto más peligroso del rival
+	// (board.FindOpenThrees), se prefiere el hijo más visitado que sí lo corta; 0 lo desactiva
+	LowConfidence float64
+
+	// EvalCacheSize es el máximo de posiciones en la caché de evaluaciones de la
+	// simulación (por hash Zobrist); 0 la desactiva. Ver ClearCache
+	EvalCacheSize int
+
+	// EarlyStopShare termina la búsqueda antes de tiempo cuando el hijo más visitado
+	// de la raíz supera esta proporción (0-1) de las visitas, tras al menos
+	// EarlyStopMin simulaciones; 0 lo desactiva
+	EarlyStopShare float64
+	EarlyStopMin   int
+
+	// RAVE es la constante de equivalencia k con que se mezcla el valor AMAF
+	// (all-moves-as-first) de cada hijo con su valor UCB:
+	// beta = sqrt(k / (3*visits + k)), alto con pocas visitas; 0 lo desactiva (ver rave.go)
+	RAVE float64
+
+	// EndgameCells: con esta cantidad de casillas vacías o menos, Search resuelve
+	// la posición de forma exacta (board.SolveEndgameMove) y solo busca con MCTS
+	// si la posición está perdida; 0 lo desactiva
+	EndgameCells int
+
+	// Rules son las reglas con que se generan los movimientos, se detectan las
+	// victorias y se evalúan las posiciones (incluidos los topes de
+	// GenerateSmartMoves y ExactWinLength); el valor cero usa board.DefaultRules.
+	// Al cambiarlas conviene llamar a ClearCache
+	Rules board.Rules
+
+	// Book es un libro de aperturas (ver board.LoadOpeningBook): si la posición,
+	// o una girada o reflejada, está en el libro se juega su movimiento sin
+	// buscar (ver board.BookMove); nil lo desactiva
+	Book map[uint64]board.Move
+
+	root  *Node            // raíz retenida cuando ReuseTree está activo
+	rng   *rand.Rand       // generador propio (uno por worker en paralelo)
+	table map[uint64]*Node // tabla de transposición: hash Zobrist => nodo
+
+	rootNoise map[board.Move]float64 // ruido por movimiento de la raíz (normalizado)
+	cache     *evalCache             // compartida por copia con los workers
+}
+
+// NewMCTS crea un motor con su propio generador aleatorio
+// Parámetros:
+// - seed: Semilla del generador (misma semilla y tablero => misma búsqueda)
+// - iterations: Límite máximo de simulaciones
+// - maxDepth: Profundidad máxima del rollout
+// - timeLimit: Límite en segundos por búsqueda
+// Retorna: Puntero a MCTS con Exploration = DefaultExploration
+// CriticalBlockLen = DefaultCriticalBlockLen, LowConfidence = DefaultLowConfidence
+// VirtualLoss = DefaultVirtualLoss, EvalCacheSize = DefaultEvalCacheSize
+// EarlyStopMin = DefaultEarlyStopMin (la parada temprana queda desactivada)
+// y EndgameCells = DefaultEndgameCells
+func NewMCTS(seed int64, iterations, maxDepth, timeLimit int) *MCTS {
+	return &MCTS{
+		Iterations:       iterations,
+		Exploration:      DefaultExploration,
+		MaxDepth:         maxDepth,
+		TimeLimit:        timeLimit,
+		CriticalBlockLen: DefaultCriticalBlockLen,
+		LowConfidence:    DefaultLowConfidence,
+		VirtualLoss:      DefaultVirtualLoss,
+		EvalCacheSize:    DefaultEvalCacheSize,
+		EarlyStopMin:     DefaultEarlyStopMin,
+		EndgameCells:     DefaultEndgameCells,
+		rng:              rand.New(rand.NewSource(seed)),
+	}
 }
 
 // Node para el árbol de búsqueda
+// Con transposiciones (UseTranspositions) un nodo puede colgar de varios padres y
+// alcanzarse con movimientos distintos: por eso el movimiento que lleva a él se
+// guarda en la arista (edge) de cada padre y no en el nodo
 type Node struct {
 	board        board.Board
-	parent       *Node
-	children     []*Node
+	children     []edge
 	visits       int
 	wins         float64
-	untriedMoves []board.Move
-	move         board.Move // movimiento que llevó a este nodo
-	player       rune       // jugador que hizo el movimiento 'move' en este nodo
-	movesInTurn  int        // cuántos movimientos se han hecho en el turno actual (0,1,2)
+	untriedMoves []board.Move // ordenados de mejor a peor (orderMoves)
+	player       rune         // jugador que hizo el movimiento que llevó a este nodo
 }
 
-// NewNode crea un nodo dado un estado y jugador actual
-func NewNode(b board.Board, move board.Move, parent *Node, player rune, movesInTurn int) *Node {
+// edge une un nodo con uno de sus hijos
+type edge struct {
+	move       board.Move // movimiento que lleva del padre al hijo (un turno completo)
+	node       *Node
+	raveVisits int     // simulaciones en que el jugador del hijo jugó alguna piedra de 'move' más tarde (AMAF)
+	raveWins   float64 // victorias de esas simulaciones, desde el jugador del hijo
+}
+
+// NewNode crea un nodo dado un estado y el jugador que movió para llegar a él
+// Los movimientos sin probar se ordenan una sola vez, aquí, con board.QuickMoveScore
+func NewNode(b board.Board, player rune) *Node {
+	return newNode(board.DefaultRules, b, player)
+}
+
+// newNode es NewNode generando y ordenando los movimientos con 'rules'
+func newNode(rules board.Rules, b board.Board, player rune) *Node {
 	return &Node{
 		board:        b,
-		parent:       parent,
-		move:         move,
 		player:       player,
-		movesInTurn:  movesInTurn,
-		visits:       0,
-		wins:         0.0,
-		untriedMoves: board.GenerateSmartMoves(b),
+		untriedMoves: orderMoves(rules, b, rules.GenerateSmartMoves(b), board.SwitchPlayer(player)),
+	}
+}
+
+// rules retorna las reglas del motor: m.Rules, o board.DefaultRules si es el valor cero
+func (m *MCTS) rules() board.Rules {
+	if m.Rules.WinLength == 0 {
+		return board.DefaultRules
 	}
+	return m.Rules
 }
 
-// Search inicia la búsqueda MCTS
-func (m *MCTS) Search(state board.Board) board.Move {
-	rand.Seed(time.Now().UnixNano())
+// Search inicia la búsqueda MCTS limitada por MoveTime
+// Parámetros:
+// - state: Tablero actual
+// - currentPlayer: Jugador para el que se busca el movimiento
+// Retorna: Mejor movimiento y true, o Move{} y false si no hay movimientos legales
+func (m *MCTS) Search(state board.Board, currentPlayer rune) (board.Move, bool) {
+	// Control de tiempo: deadline
+	ctx, cancel := m.withMoveTime(state)
+	defer cancel()
+
+	return m.SearchContext(ctx, state, currentPlayer)
+}
+
+// SearchContext ejecuta la búsqueda MCTS hasta agotar Iterations o cancelar 'ctx'
+// Parámetros:
+// - ctx: Contexto de cancelación (al cancelarse se devuelve lo mejor hallado)
+// - state: Tablero actual
+// - currentPlayer: Jugador que debe mover
+// Retorna: Mejor movimiento encontrado y true, o Move{} y false si no hay movimientos legales
+func (m *MCTS) SearchContext(ctx context.Context, state board.Board, currentPlayer rune) (board.Move, bool) {
+	if move, ok := m.bookMove(state); ok {
+		return move, true
+	}
+	// Victoria inmediata: no hace falta buscar
+	if win, ok := m.bestWinningMove(state, currentPlayer); ok {
+		return win, true
+	}
+	// Final con pocas casillas: resultado exacto, salvo que esté perdido
+	// (entonces MCTS elige la defensa que más resiste en la práctica)
+	if m.EndgameCells > 0 {
+		move, winner, ok := m.rules().SolveEndgameMove(state, currentPlayer, m.EndgameCells)
+		if ok && winner != board.SwitchPlayer(currentPlayer) && move != (board.Move{}) {
+			return move, true
+		}
+	}
+
+	root := m.searchTree(ctx, state, currentPlayer)
+	// Elegimos el hijo con el mayor número de visitas (o mayor ratio wins)
+	return m.getBestMove(root)
+}
+
+// bestWinningMove elige entre las victorias inmediatas de 'player'
+// (board.FindAllWinningMoves) la que deja al rival la peor evaluación
+// Retorna: El movimiento y true, o false si no hay victoria inmediata
+func (m *MCTS) bestWinningMove(state board.Board, player rune) (board.Move, bool) {
+	rules := m.rules()
+	wins := rules.FindAllWinningMoves(state, player)
+	if len(wins) == 0 {
+		return board.Move{}, false
+	}
+	opponent := board.SwitchPlayer(player)
+	best, bestEval := wins[0], math.Inf(1)
+	for _, win := range wins {
+		after := state
+		board.ApplyMove(&after, win, player)
+		if eval := rules.EvaluateBoard(after, opponent); eval < bestEval {
+			best, bestEval = win, eval
+		}
+	}
+	return best, true
+}
 
-	// Determinamos quién es el jugador actual
-	currentPlayer := board.GetCurrentPlayer(state)
-	// Creamos la raíz
-	root := NewNode(state, board.Move{}, nil, board.SwitchPlayer(currentPlayer), 0)
+// bookMove busca la posición en el libro de aperturas (ver board.BookMove)
+// Retorna: Movimiento del libro y true si existe, en cualquier simetría, y es legal en 'state'
+func (m *MCTS) bookMove(state board.Board) (board.Move, bool) {
+	return board.BookMove(m.Book, state)
+}
+
+// searchTree construye el árbol de búsqueda y retorna su raíz
+// (con Workers > 1, una raíz que combina las estadísticas de todos los árboles)
+func (m *MCTS) searchTree(ctx context.Context, state board.Board, currentPlayer rune) *Node {
+	// La caché se crea antes de copiar el motor a los workers para que la compartan
+	if m.EvalCacheSize > 0 && m.cache == nil {
+		m.cache = &evalCache{size: m.EvalCacheSize}
+	}
+	if m.Workers > 1 && !m.SharedTree {
+		return m.searchParallel(ctx, state, currentPlayer)
+	}
+
+	// Un MCTS creado sin NewMCTS se siembra con el reloj
+	if m.rng == nil {
+		m.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	// Creamos la raíz, o reutilizamos la retenida si corresponde a esta posición
+	root := m.root
+	if !m.ReuseTree || root == nil || root.board != state || root.player != board.SwitchPlayer(currentPlayer) {
+		root = newNode(m.rules(), state, board.SwitchPlayer(currentPlayer))
+	}
 	// Definimos 'player' como si fuera "quién movió para llegar aquí".
+	if m.ReuseTree {
+		m.root = root
+	}
 
-	// Control de tiempo: deadline
-	deadline := time.Now().Add(time.Duration(m.TimeLimit) * time.Second)
+	m.prepareRoot(root)
+	if m.Workers > 1 {
+		m.iterateShared(ctx, root)
+	} else {
+		left := int64(m.Iterations)
+		m.iterate(ctx, root, &left)
+	}
+	return root
+}
 
-	for i := 0; i < m.Iterations; i++ {
-		if time.Now().After(deadline) {
-			break
+// prepareRoot reinicia el estado por búsqueda asociado a la raíz:
+// la tabla de transposición, los bloqueos críticos y, si RootNoise > 0, el ruido de Dirichlet
+func (m *MCTS) prepareRoot(root *Node) {
+	// Una raíz nueva solo prueba movimientos que bloquean las amenazas del rival
+	if m.CriticalBlockLen > 0 && root.visits == 0 && len(root.children) == 0 {
+		root.untriedMoves = blockingMoves(m.rules(), root.board, root.player, m.CriticalBlockLen, root.untriedMoves)
+	}
+
+	// Tabla de transposición nueva en cada búsqueda
+	m.table = nil
+	if m.UseTranspositions {
+		m.table = map[uint64]*Node{board.ZobristHash(root.board): root}
+	}
+
+	m.rootNoise = nil
+	if m.RootNoise > 0 {
+		m.rootNoise = m.dirichletNoise(root)
+	}
+}
+
+// blockingMoves filtra 'moves' a los que ocupan alguna casilla crítica de 'opponent'
+// Si ninguno lo hace se generan pares casilla crítica + casilla cercana;
+// sin casillas críticas 'moves' se retorna sin cambios
+// Los pares generados se ordenan con 'rules' (ver orderMoves)
+func blockingMoves(rules board.Rules, b board.Board, opponent rune, minLen int, moves []board.Move) []board.Move {
+	if board.MoveStoneCount(b) == 1 {
+		return moves
+	}
+	blocks := board.FindCriticalBlocksN(b, opponent, minLen)
+	if len(blocks) == 0 {
+		return moves
+	}
+	critical := make(map[board.Position]bool, len(blocks))
+	for _, p := range blocks {
+		critical[p] = true
+	}
+
+	var filtered []board.Move
+	for _, move := range moves {
+		if critical[move[0]] || critical[move[1]] {
+			filtered = append(filtered, move)
 		}
+	}
+	if len(filtered) > 0 {
+		return filtered
+	}
+
+	for _, p := range blocks {
+		for _, q := range board.GetPriorityPositions(b, 1) {
+			if p != q && len(filtered) < maxBlockMoves {
+				filtered = append(filtered, board.Move{p, q})
+			}
+		}
+	}
+	return orderMoves(rules, b, filtered, board.SwitchPlayer(opponent))
+}
+
+// iterate ejecuta ciclos MCTS sobre 'root' mientras quede presupuesto en 'left'
+// (compartido entre workers en searchParallel) o hasta cancelar 'ctx'
+func (m *MCTS) iterate(ctx context.Context, root *Node, left *int64) {
+	for i := 0; ctx.Err() == nil && !m.confident(root, i) && takeIteration(left); i++ {
 		// 1) Selection
-		node := m.selectNode(root)
+		path := m.selectNode(root)
+		node := path[len(path)-1]
 		// 2) Expansion
 		expanded := m.expand(node)
+		if expanded != node {
+			path = append(path, expanded)
+		}
 		// 3) Simulation (rollout)
-		result := m.rollout(expanded)
+		result, final := m.rollout(expanded)
 		// 4) Backpropagation
-		m.backpropagate(expanded, result)
+		m.backpropagate(path, result)
+		if m.RAVE > 0 {
+			m.backpropagateRAVE(path, final, result)
+		}
 	}
-	// Elegimos el hijo con el mayor número de visitas (o mayor ratio wins)
-	return m.getBestMove(root)
 }
 
-// selectNode recorre el árbol hasta llegar a un nodo no completamente expandido
-func (m *MCTS) selectNode(node *Node) *Node {
+// Advance desciende la raíz retenida al hijo correspondiente a 'move'
+// Se llama tras cada jugada (propia o del rival) para conservar sus estadísticas.
+// Si el movimiento no se había explorado, la raíz se descarta.
+func (m *MCTS) Advance(move board.Move) {
+	if !m.ReuseTree || m.root == nil {
+		return
+	}
+	for _, e := range m.root.children {
+		if sameMove(e.move, move) {
+			m.root = e.node
+			return
+		}
+	}
+	m.root = nil
+}
+
+// sameMove compara dos movimientos sin importar el orden de las piedras
+func sameMove(a, b board.Move) bool {
+	return a == b || (a[0] == b[1] && a[1] == b[0])
+}
+
+// selectNode recorre el árbol hasta llegar a un nodo que pueda expandirse (canExpand)
+// Retorna: El camino recorrido desde 'node'; el último elemento es el nodo elegido.
+// Con transposiciones un nodo puede tener varios padres, por eso se guarda el camino.
+func (m *MCTS) selectNode(node *Node) []*Node {
 	current := node
-	for len(current.untriedMoves) == 0 && len(current.children) > 0 {
-		current = m.ucbSelect(current)
+	path := []*Node{current}
+	for !m.canExpand(current) && len(current.children) > 0 {
+		current = m.ucbSelect(current, current == node)
+		path = append(path, current)
 	}
-	return current
+	return path
 }
 
 // ucbSelect elige el hijo con mayor valor UCB
-func (m *MCTS) ucbSelect(node *Node) *Node {
+// Los hijos sin visitas tienen prioridad infinita; ante empate gana el primero
+// 'atRoot' indica que 'node' es la raíz de la búsqueda (donde se mezcla el ruido)
+func (m *MCTS) ucbSelect(node *Node, atRoot bool) *Node {
 	var bestNode *Node
-	bestValue := -math.MaxFloat64
+	bestValue := math.Inf(-1)
 
-	for _, child := range node.children {
-		ucb := m.ucbValue(child, node.visits)
-		if ucb > bestValue {
+	// En la raíz se mezcla el ruido de Dirichlet (media 1) con el valor UCB
+	mixNoise := m.rootNoise != nil && atRoot
+
+	for i := range node.children {
+		e := &node.children[i]
+		ucb := m.ucbValue(e, node.visits)
+		if mixNoise {
+			ucb = (1-m.RootNoise)*ucb + m.RootNoise*m.rootNoise[normalizeMove(e.move)]
+		}
+		if bestNode == nil || ucb > bestValue {
 			bestValue = ucb
-			bestNode = child
+			bestNode = e.node
 		}
 	}
 	return bestNode
 }
 
-// ucbValue calcula UCB = (wins/visits) + C * sqrt( ln(parentVisits)/visits )
-func (m *MCTS) ucbValue(node *Node, parentVisits int) float64 {
+// ucbValue calcula UCB = (wins/visits) + C * sqrt( ln(parentVisits)/visits ) del hijo de 'e'
+// Nunca retorna NaN: sin visitas del hijo => +Inf, sin visitas del padre => solo explotación
+func (m *MCTS) ucbValue(e *edge, parentVisits int) float64 {
+	node := e.node
 	if node.visits == 0 {
 		return math.Inf(1)
 	}
-	exploit := node.wins / float64(node.visits)
+	exploit := m.raveBlend(e, node.wins/float64(node.visits))
+	if parentVisits <= 1 {
+		// ln(1) = 0 y ln(0) = -Inf: no hay término de exploración válido
+		return exploit
+	}
 	explore := math.Sqrt(math.Log(float64(parentVisits)) / float64(node.visits))
 	return exploit + m.Exploration*explore
 }
 
+// expand añade a 'node' un hijo con el mejor de sus movimientos sin probar
+// (untriedMoves ya viene ordenado de NewNode)
+// Retorna: El hijo nuevo (o compartido por transposición), o 'node' si no se puede expandir
 func (m *MCTS) expand(node *Node) *Node {
-	if len(node.untriedMoves) == 0 {
+	if !m.canExpand(node) {
 		return node
 	}
-	moveIdx := rand.Intn(len(node.untriedMoves))
-	move := node.untriedMoves[moveIdx]
-	node.untriedMoves = append(node.untriedMoves[:moveIdx], node.untriedMoves[moveIdx+1:]...)
+	move := node.untriedMoves[0]
+	node.untriedMoves = node.untriedMoves[1:]
 
 	newBoard := board.CloneBoard(node.board)
-	currentPlayer := node.player
-	movesInTurn := node.movesInTurn + 1
-
-	// Cambia de jugador tras 2 movimientos (Connect6)
-	if movesInTurn == 2 {
-		currentPlayer = board.SwitchPlayer(node.player)
-		movesInTurn = 0
-	}
+	// Cada Move ya contiene las piedras del turno: el jugador alterna en cada nivel
+	currentPlayer := board.SwitchPlayer(node.player)
 
 	board.ApplyMove(&newBoard, move, currentPlayer)
 
-	child := NewNode(newBoard, move, node, currentPlayer, movesInTurn)
-	node.children = append(node.children, child)
+	// Si la posición ya existe en la tabla, se comparte el nodo y sus estadísticas
+	var hash uint64
+	if m.table != nil {
+		hash = board.ZobristHash(newBoard)
+		if existing, ok := m.table[hash]; ok {
+			node.children = append(node.children, edge{move: move, node: existing})
+			return existing
+		}
+	}
+
+	child := newNode(m.rules(), newBoard, currentPlayer)
+	node.children = append(node.children, edge{move: move, node: child})
+	if m.table != nil {
+		m.table[hash] = child
+	}
 	return child
 }
 
 // rollout ejecuta la fase de simulación hasta MaxDepth o estado terminal
-func (m *MCTS) rollout(node *Node) float64 {
+// Retorna: El resultado (0-1) desde quien movió para llegar a 'node' y el
+// tablero final de la simulación (lo usa RAVE)
+func (m *MCTS) rollout(node *Node) (float64, board.Board) {
 	state := board.CloneBoard(node.board)
-	currentPlayer := node.player
-	originalPlayer := currentPlayer
-	movesInTurn := node.movesInTurn
+	// El resultado se mide desde quien movió para llegar al nodo
+	originalPlayer := node.player
+	currentPlayer := board.SwitchPlayer(originalPlayer)
+
+	// Verificar si la partida ya terminó en el nodo (tablero completo, una sola vez)
+	rules := m.rules()
+	if over, winner := rules.IsTerminal(state); over {
+		switch winner {
+		case originalPlayer:
+			return 1.0, state
+		case ' ':
+			return 0.5, state // tablero lleno sin ganador: empate
+		}
+		return 0.0, state
+	}
+	// Sin movimientos legales (p.ej. queda una casilla y el turno pide dos
+	// piedras) el nodo es terminal: nadie puede ganar, cuenta como empate
+	if board.LegalMoveCount(state) == 0 {
+		return 0.5, state
+	}
 
+	// Casillas vacías al día durante la simulación: con el tablero casi lleno
+	// la generación recorre solo las vacías (ver board.Rules.GenerateSmartMovesIn)
+	empties := board.NewEmptySet(state)
 	for depth := 0; depth < m.MaxDepth; depth++ {
-		// Verificar si alguien ganó
-		if board.CheckWin(state, 'B') {
-			if originalPlayer == 'B' {
-				return 1.0
-			} else {
-				return 0.0
-			}
-		} else if board.CheckWin(state, 'W') {
-			if originalPlayer == 'W' {
-				return 1.0
-			} else {
-				return 0.0
-			}
+		if board.LegalMoveCount(state) == 0 {
+			return 0.5, state // igual que arriba: empate
 		}
 
-		moves := board.GenerateSmartMoves(state)
-		if len(moves) == 0 {
+		// Un turno completo (las piedras del turno van en el mismo Move). Sin
+		// política se sortea entre los movimientos generados; con política solo
+		// ella genera y consume el generador, así la misma semilla da la misma
+		// simulación con RolloutPolicy = nil que con RandomRollout
+		var move board.Move
+		if m.RolloutPolicy != nil {
+			move = m.RolloutPolicy(state, currentPlayer, m.rng)
+		} else if moves := rules.GenerateSmartMovesIn(state, empties); len(moves) > 0 {
+			move = moves[m.rng.Intn(len(moves))]
+		}
+		if move == (board.Move{}) {
+			// Quedan jugadas legales que no se proponen: se puntúa la
+			// posición en lugar de darla por empatada
 			break
 		}
+		board.ApplyMove(&state, move, currentPlayer)
+		empties.Apply(move)
 
-		// Realizar 2 movimientos (connect6)
-		for i := 0; i < 2; i++ {
-			if len(moves) == 0 {
-				break
-			}
-
-			move := m.policyMove(state, moves, currentPlayer)
-			board.ApplyMove(&state, move, currentPlayer)
-			movesInTurn++
-
-			if board.CheckWin(state, 'B') || board.CheckWin(state, 'W') {
-				break
-			}
-
-			if movesInTurn == 2 {
-				currentPlayer = board.SwitchPlayer(currentPlayer)
-				movesInTurn = 0
+		// Solo las piedras recién colocadas pueden formar una victoria nueva
+		if rules.MoveWins(state, move, currentPlayer) {
+			if currentPlayer == originalPlayer {
+				return 1.0, state
 			}
+			return 0.0, state
 		}
+		currentPlayer = board.SwitchPlayer(currentPlayer)
 	}
 
-	eval := board.EvaluateBoard(state, originalPlayer)
-	if eval <= 0 {
-		return 0.0
+	return squash(m.evaluate(state, originalPlayer)), state
+}
+
+// squash convierte una evaluación heurística en un valor en (0,1) con una
+// logística: 0 => 0.5 y cada rolloutEvalScale de ventaja acerca el valor a 1
+// (o a 0 si es desventaja), de modo que las posiciones claras pesan más
+func squash(eval float64) float64 {
+	return 1 / (1 + math.Exp(-eval/rolloutEvalScale))
+}
+
+// HeuristicRollout es la política de simulación heurística, para usar como
+// RolloutPolicy (p.ej. engine.RolloutPolicy = engine.HeuristicRollout):
+// juega mejor que el azar pero cada jugada cuesta muchas evaluaciones
+// Usa la caché de evaluación del motor 'm' al que queda ligada
+func (m *MCTS) HeuristicRollout(b board.Board, player rune, rng *rand.Rand) board.Move {
+	moves := m.rules().GenerateSmartMoves(b)
+	if len(moves) == 0 {
+		return board.Move{}
 	}
-	return 1.0
+	return m.policyMove(b, moves, player, rng)
 }
 
 // policyMove: elige un movimiento durante la simulación.
@@ -192,14 +582,15 @@ func (m *MCTS) rollout(node *Node) float64 {
 // 2) Bloqueo
 // 3) EvaluateBoard
 // Con pequeña aleatoriedad
-func (m *MCTS) policyMove(state board.Board, moves []board.Move, currentPlayer rune) board.Move {
+func (m *MCTS) policyMove(state board.Board, moves []board.Move, currentPlayer rune, rng *rand.Rand) board.Move {
 	// 1) Movida ganadora tuya
-	if winMove := board.FindPairWinningMove(state, currentPlayer); winMove != nil {
+	rules := m.rules()
+	if winMove := rules.FindPairWinningMove(state, currentPlayer); winMove != nil {
 		return *winMove
 	}
 	// 2) Bloqueo movida ganadora rival
 	opponent := board.SwitchPlayer(currentPlayer)
-	if blockMove := board.FindPairWinningMove(state, opponent); blockMove != nil {
+	if blockMove := rules.FindPairWinningMove(state, opponent); blockMove != nil {
 		return *blockMove
 	}
 
@@ -213,7 +604,7 @@ func (m *MCTS) policyMove(state board.Board, moves []board.Move, currentPlayer r
 		// Jugada nuestra
 		board.ApplyMove(&tmp, mv, currentPlayer)
 		// Evaluación del rival tras esto
-		oppVal := board.EvaluateBoard(tmp, opponent)
+		oppVal := m.evaluate(tmp, opponent)
 		if oppVal < bestBlockEval {
 			bestBlockEval = oppVal
 			copyMove := mv
@@ -228,7 +619,7 @@ func (m *MCTS) policyMove(state board.Board, moves []board.Move, currentPlayer r
 
 	if bestBlockMove != nil {
 		// Checa la evaluación del rival en la posición actual
-		currentRivalVal := board.EvaluateBoard(state, opponent)
+		currentRivalVal := m.evaluate(state, opponent)
 		// si la diferencia es grande, bloquea
 		if currentRivalVal-bestBlockEval > 10000 {
 			// => hay un gran cambio => haremos ese blocking
@@ -238,12 +629,12 @@ func (m *MCTS) policyMove(state board.Board, moves []board.Move, currentPlayer r
 
 	// 4) Heurística “positiva” => elegimos la que me da mejor EvaluateBoard
 	bestScore := -math.MaxFloat64
-	bestMove := moves[rand.Intn(len(moves))]
+	bestMove := moves[rng.Intn(len(moves))]
 
 	for _, mv := range moves {
 		tmp := board.CloneBoard(state)
 		board.ApplyMove(&tmp, mv, currentPlayer)
-		sc := board.EvaluateBoard(tmp, currentPlayer)
+		sc := m.evaluate(tmp, currentPlayer)
 		if sc > bestScore {
 			bestScore = sc
 			bestMove = mv
@@ -253,45 +644,149 @@ func (m *MCTS) policyMove(state board.Board, moves []board.Move, currentPlayer r
 	return bestMove
 }
 
-// backpropagate recorre hacia arriba y ajusta visits/wins
-func (m *MCTS) backpropagate(node *Node, result float64) {
-	current := node
-	for current != nil {
+// backpropagate recorre el camino de selección y ajusta visits/wins
+// 'result' está en la perspectiva del último nodo; cada nodo guarda las
+// victorias del jugador que movió para llegar a él
+func (m *MCTS) backpropagate(path []*Node, result float64) {
+	leafPlayer := path[len(path)-1].player
+	for _, current := range path {
 		current.visits++
-		current.wins += result
-
-		current = current.parent
+		if current.player == leafPlayer {
+			current.wins += result
+		} else {
+			current.wins += 1 - result
+		}
 	}
 }
 
 // getBestMove elige el movimiento en el hijo con el mayor número de visitas
-func (m *MCTS) getBestMove(root *Node) board.Move {
+func (m *MCTS) getBestMove(root *Node) (board.Move, bool) {
 	// 1) Buscar jugadas ganadoras en profundidad 1
-	for _, child := range root.children {
-		if child.movesInTurn == 0 {
-			// si completó 2 movidas
-			if board.CheckWin(child.board, root.player) {
-				return child.move
-			}
+	for _, e := range root.children {
+		if m.rules().MoveWins(e.node.board, e.move, e.node.player) {
+			return e.move, true
+		}
+	}
+
+	// 2) Con temperatura, muestrear según las visitas
+	if m.Temperature > 0 {
+		if e := m.sampleByVisits(root); e != nil {
+			return m.avoidBlunder(root, e.move), true
+		}
+	}
+
+	// 3) Elegir el hijo más visitado
+	var best *edge
+	for i := range root.children {
+		if e := &root.children[i]; best == nil || e.node.visits > best.node.visits {
+			best = e
+		}
+	}
+
+	// 4) Con poca confianza, cortar el tres abierto más peligroso del rival
+	if alt := m.openThreeBlock(root, best); alt != nil {
+		best = alt
+	}
+
+	if best == nil {
+		// Sin hijos: primero las jugadas heurísticas, luego cualquier jugada legal
+		if moves := m.rules().GenerateSmartMoves(root.board); len(moves) > 0 {
+			return moves[0], true
+		}
+		return anyLegalMove(root.board)
+	}
+	// 5) Revisión final: no dejar una victoria inmediata al rival
+	return m.avoidBlunder(root, best.move), true
+}
+
+// openThreeBlock aplica la regla de LowConfidence sobre la arista elegida 'best'
+// Un bloqueo de una casilla crítica del rival (board.FindCriticalBlocksN) es
+// obligatorio y nunca se cambia por el corte de un tres abierto
+// Retorna: La arista al hijo más visitado que ocupa la casilla que corta el tres
+// abierto más peligroso del rival, o nil si no hace falta cambiar de elección
+func (m *MCTS) openThreeBlock(root *Node, best *edge) *edge {
+	if m.LowConfidence <= 0 || best == nil || best.node.visits == 0 ||
+		best.node.wins/float64(best.node.visits) >= m.LowConfidence {
+		return nil
+	}
+	if blocksCritical(root.board, root.player, best.move) {
+		return nil
+	}
+	threes := m.rules().FindOpenThrees(root.board, root.player)
+	if len(threes) == 0 {
+		return nil
+	}
+	target := threes[0]
+	if best.move[0] == target || best.move[1] == target {
+		return nil
+	}
+
+	var alt *edge
+	for i := range root.children {
+		e := &root.children[i]
+		if (e.move[0] == target || e.move[1] == target) && (alt == nil || e.node.visits > alt.node.visits) {
+			alt = e
 		}
 	}
+	return alt
+}
 
-	// 2) Elegir el hijo más visitado
-	var bestChild *Node
-	bestVisits := -1
-	for _, child := range root.children {
-		if child.visits > bestVisits {
-			bestVisits = child.visits
-			bestChild = child
+// blocksCritical indica si 'move' ocupa alguna casilla crítica de 'opponent'
+// (cadenas de DefaultCriticalBlockLen o cuatros partidos)
+func blocksCritical(b board.Board, opponent rune, move board.Move) bool {
+	for _, p := range board.FindCriticalBlocksN(b, opponent, DefaultCriticalBlockLen) {
+		if move[0] == p || move[1] == p {
+			return true
 		}
 	}
+	return false
+}
 
-	if bestChild == nil {
-		moves := board.GenerateSmartMoves(root.board)
-		if len(moves) == 0 {
-			return board.Move{}
+// anyLegalMove retorna la primera jugada legal en orden de filas (las primeras celdas vacías)
+// Retorna: Move{} y false si quedan menos celdas vacías que piedras por colocar
+func anyLegalMove(b board.Board) (board.Move, bool) {
+	move := board.Move{board.NoPosition, board.NoPosition}
+	need := board.MoveStoneCount(b)
+	found := 0
+	for r := 0; r < board.BoardSize && found < need; r++ {
+		for c := 0; c < board.BoardSize && found < need; c++ {
+			if b[r][c] == '\x00' {
+				move[found] = board.Position{Row: r, Col: c}
+				found++
+			}
+		}
+	}
+	if found < need {
+		return board.Move{}, false
+	}
+	return move, true
+}
+
+// sampleByVisits elige un hijo con probabilidad proporcional a visits^(1/Temperature)
+// Retorna: La arista elegida, o nil si ningún hijo tiene visitas
+func (m *MCTS) sampleByVisits(root *Node) *edge {
+	weights := make([]float64, len(root.children))
+	total := 0.0
+	for i, e := range root.children {
+		weights[i] = math.Pow(float64(e.node.visits), 1/m.Temperature)
+		total += weights[i]
+	}
+	if total == 0 || math.IsInf(total, 1) {
+		return nil
+	}
+
+	r := m.rng.Float64() * total
+	var last *edge
+	for i := range root.children {
+		if weights[i] == 0 {
+			continue
+		}
+		last = &root.children[i]
+		r -= weights[i]
+		if r < 0 {
+			return last
 		}
-		return moves[0]
 	}
-	return bestChild.move
+	// Redondeo: el último hijo con peso
+	return last
 }
@@ -0,0 +1,370 @@
+package mcts
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"connect6/board"
+)
+
+// openingBoard es una posición de tres piedras (apertura y primera respuesta)
+func openingBoard() board.Board {
+	var b board.Board
+	b[9][9] = 'B'
+	b[9][10] = 'W'
+	b[10][9] = 'W'
+	return b
+}
+
+// BenchmarkSearch mide el costo y las asignaciones de una búsqueda corta
+// (la generación de movimientos domina las asignaciones: ver winningWindows)
+func BenchmarkSearch(b *testing.B) {
+	state := openingBoard()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m := NewMCTS(1, 50, 4, 60)
+		m.RolloutPolicy = RandomRollout
+		m.Search(state, 'B')
+	}
+}
+
+// TestTranspositionSharesNodeAndKeepsEdgeMoves llega a la misma posición por dos
+// órdenes de jugadas: el nodo se comparte, pero cada padre conserva su propio
+// movimiento hacia él
+func TestTranspositionSharesNodeAndKeepsEdgeMoves(t *testing.T) {
+	p := func(r, c int) board.Position { return board.Position{Row: r, Col: c} }
+	m := NewMCTS(1, 0, 0, 1)
+	m.UseTranspositions = true
+	root := NewNode(openingBoard(), 'W')
+	m.prepareRoot(root)
+
+	// Camino 1: B (5,5)+(5,6), W (1,1)+(1,2), B (5,7)+(5,8)
+	// Camino 2: B (5,5)+(5,7), W (1,1)+(1,2), B (5,6)+(5,8)
+	expandWith := func(node *Node, move board.Move) *Node {
+		node.untriedMoves = []board.Move{move}
+		return m.expand(node)
+	}
+	a1 := expandWith(root, board.Move{p(5, 5), p(5, 6)})
+	a2 := expandWith(a1, board.Move{p(1, 1), p(1, 2)})
+	shared := expandWith(a2, board.Move{p(5, 7), p(5, 8)})
+
+	b1 := expandWith(root, board.Move{p(5, 5), p(5, 7)})
+	b2 := expandWith(b1, board.Move{p(1, 1), p(1, 2)})
+	again := expandWith(b2, board.Move{p(5, 6), p(5, 8)})
+
+	if again != shared {
+		t.Fatalf("la posición transpuesta no reutilizó el nodo existente")
+	}
+	for _, parent := range []*Node{a2, b2} {
+		e := parent.children[0]
+		after := parent.board
+		board.ApplyMove(&after, e.move, e.node.player)
+		if after != e.node.board {
+			t.Errorf("la arista %v no lleva de su padre al nodo compartido", e.move)
+		}
+	}
+	if a2.children[0].move == b2.children[0].move {
+		t.Errorf("ambos padres guardan el mismo movimiento %v", a2.children[0].move)
+	}
+}
+
+// drawnBoard llena el tablero sin seis en línea: parejas alternas por fila,
+// desplazadas una columna de pareja en cada fila
+func drawnBoard() board.Board {
+	var b board.Board
+	for r := 0; r < board.BoardSize; r++ {
+		for c := 0; c < board.BoardSize; c++ {
+			if (c/2+r)%2 == 0 {
+				b[r][c] = 'B'
+			} else {
+				b[r][c] = 'W'
+			}
+		}
+	}
+	return b
+}
+
+// TestSearchOnBoardWithoutLegalMoves busca desde un tablero lleno sin ganador
+// y desde uno con una sola casilla libre (el turno pide dos piedras): no hay
+// jugada posible y la simulación cuenta el nodo como empate
+func TestSearchOnBoardWithoutLegalMoves(t *testing.T) {
+	full := drawnBoard()
+	if over, winner := board.IsTerminal(full); !over || winner != ' ' {
+		t.Fatalf("el tablero de prueba debe ser un empate, got over=%v winner=%q", over, winner)
+	}
+	oneLeft := drawnBoard()
+	oneLeft[0][0] = 0
+
+	for name, state := range map[string]board.Board{"lleno": full, "una casilla": oneLeft} {
+		m := NewMCTS(1, 20, 4, 5)
+		if _, ok := m.Search(state, 'W'); ok {
+			t.Errorf("%s: Search devolvió una jugada sin movimientos legales", name)
+		}
+		if v, _ := m.rollout(NewNode(state, 'B')); v != 0.5 {
+			t.Errorf("%s: rollout = %v, want 0.5 (empate)", name, v)
+		}
+	}
+}
+
+// TestSearchUsesEngineRules: con ExactWinLength una sobrelínea no cuenta como
+// victoria inmediata, y los topes de generación de m.Rules limitan la raíz
+func TestSearchUsesEngineRules(t *testing.T) {
+	exact := board.Rules{WinLength: board.WinLength, ExactWinLength: true}
+	// Cinco negras en (9,2)..(9,6), cerradas en (9,1), y otra en (9,8):
+	// la única casilla que completa la fila, (9,7), haría siete
+	var b board.Board
+	for c := 2; c <= 6; c++ {
+		b[9][c] = 'B'
+	}
+	b[9][8] = 'B'
+	b[9][1] = 'W'
+	for c := 0; c < 5; c++ {
+		b[0][c*3] = 'W'
+	}
+
+	m := NewMCTS(1, 50, 4, 60)
+	if _, ok := m.bestWinningMove(b, 'B'); !ok {
+		t.Fatal("reglas estándar: la sobrelínea debería ganar")
+	}
+	m.Rules = exact
+	if move, ok := m.bestWinningMove(b, 'B'); ok {
+		t.Fatalf("ExactWinLength: %v tomado como victoria inmediata", move)
+	}
+
+	// Con una fila de cinco abierta en (15,3)..(15,7) sí hay victoria exacta
+	for c := 3; c <= 7; c++ {
+		b[15][c] = 'B'
+	}
+	move, ok := m.Search(b, 'B')
+	if !ok {
+		t.Fatal("Search no devolvió movimiento")
+	}
+	after := b
+	board.ApplyMove(&after, move, 'B')
+	if !exact.MoveWins(after, move, 'B') {
+		t.Errorf("%v no gana con ExactWinLength", move)
+	}
+
+	m = NewMCTS(1, 100, 4, 60)
+	m.ReuseTree = true
+	m.Rules = board.DefaultRules.WithMoveCaps(5, 8)
+	if _, ok := m.Search(openingBoard(), 'B'); !ok {
+		t.Fatal("Search no devolvió movimiento")
+	}
+	if n := len(m.root.children) + len(m.root.untriedMoves); n > 8 {
+		t.Errorf("la raíz tiene %d movimientos, want <= 8 (MaxMoves)", n)
+	}
+}
+
+// TestOpenThreeBlockKeepsCriticalBlock: con poca confianza se prefiere cortar
+// el tres abierto del rival, salvo que la elección ya bloquee un cuatro
+func TestOpenThreeBlockKeepsCriticalBlock(t *testing.T) {
+	p := func(r, c int) board.Position { return board.Position{Row: r, Col: c} }
+	var b board.Board
+	// Tres abierto de las blancas en (5,5)..(5,7): se corta en (5,4) o (5,8)
+	for c := 5; c <= 7; c++ {
+		b[5][c] = 'W'
+	}
+	b[15][15], b[15][16] = 'B', 'B'
+
+	m := NewMCTS(1, 0, 0, 1)
+	child := func(move board.Move, visits int, wins float64) edge {
+		return edge{move: move, node: &Node{visits: visits, wins: wins}}
+	}
+	root := &Node{board: b, player: 'W'}
+	root.children = []edge{
+		child(board.Move{p(0, 0), p(0, 1)}, 10, 1),
+		child(board.Move{p(5, 4), p(0, 2)}, 5, 1),
+	}
+	if alt := m.openThreeBlock(root, &root.children[0]); alt != &root.children[1] {
+		t.Fatalf("con poca confianza no se eligió el corte del tres abierto: %v", alt)
+	}
+
+	// Cuatro de las blancas en (12,3)..(12,6): el bloqueo en (12,2) se mantiene
+	for c := 3; c <= 6; c++ {
+		root.board[12][c] = 'W'
+	}
+	root.children[0] = child(board.Move{p(12, 2), p(0, 1)}, 10, 1)
+	if alt := m.openThreeBlock(root, &root.children[0]); alt != nil {
+		t.Errorf("el bloqueo del cuatro se cambió por %v", alt.move)
+	}
+}
+
+// TestZeroExplorationExploits: con Exploration = 0 el valor UCB es finito y
+// la selección es pura explotación (el hijo de mejor promedio, aunque tenga
+// muchas visitas); con exploración alta gana el hijo poco visitado
+func TestZeroExplorationExploits(t *testing.T) {
+	p := func(r, c int) board.Position { return board.Position{Row: r, Col: c} }
+	child := func(col, visits int, wins float64) edge {
+		return edge{move: board.Move{p(0, col), p(1, col)}, node: &Node{visits: visits, wins: wins}}
+	}
+	root := &Node{board: openingBoard(), player: 'W', visits: 115}
+	root.children = []edge{
+		child(0, 10, 4),   // 0.4
+		child(1, 100, 70), // 0.7: el mejor promedio
+		child(2, 5, 3),    // 0.6 con pocas visitas
+	}
+
+	m := NewMCTS(1, 0, 0, 1)
+	m.Exploration = 0
+	for i := range root.children {
+		e := &root.children[i]
+		v := m.ucbValue(e, root.visits)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			t.Fatalf("hijo %d: ucbValue = %v, want finito", i, v)
+		}
+		if want := e.node.wins / float64(e.node.visits); v != want {
+			t.Errorf("hijo %d: ucbValue = %v, want %v (solo explotación)", i, v, want)
+		}
+	}
+	if got := m.ucbSelect(root, false); got != root.children[1].node {
+		t.Errorf("Exploration 0 eligió %v, want el de mejor promedio", got)
+	}
+
+	m.Exploration = 10
+	if got := m.ucbSelect(root, false); got != root.children[2].node {
+		t.Errorf("Exploration 10 eligió %v, want el poco visitado", got)
+	}
+}
+
+// TestUnvisitedChildSelectedFirst: un hijo sin visitas no produce NaN ni
+// pánico y se elige antes que los visitados, también con el padre sin visitas
+func TestUnvisitedChildSelectedFirst(t *testing.T) {
+	p := func(r, c int) board.Position { return board.Position{Row: r, Col: c} }
+	child := func(col, visits int, wins float64) edge {
+		return edge{move: board.Move{p(0, col), p(1, col)}, node: &Node{visits: visits, wins: wins}}
+	}
+	m := NewMCTS(1, 0, 0, 1)
+	for _, parentVisits := range []int{0, 1, 20} {
+		root := &Node{board: openingBoard(), player: 'W', visits: parentVisits}
+		root.children = []edge{
+			child(0, 12, 12), // siempre gana
+			child(1, 0, 0),
+			child(2, 8, 1),
+		}
+		for i := range root.children {
+			if v := m.ucbValue(&root.children[i], parentVisits); math.IsNaN(v) {
+				t.Errorf("padre con %d visitas, hijo %d: ucbValue = NaN", parentVisits, i)
+			}
+		}
+		if v := m.ucbValue(&root.children[1], parentVisits); !math.IsInf(v, 1) {
+			t.Errorf("padre con %d visitas: el hijo sin visitas vale %v, want +Inf", parentVisits, v)
+		}
+		if got := m.ucbSelect(root, false); got != root.children[1].node {
+			t.Errorf("padre con %d visitas: se eligió %v, want el hijo sin visitas", parentVisits, got)
+		}
+	}
+}
+
+// TestSearchContextCancelled: con el contexto ya cancelado la búsqueda no
+// espera al límite de iteraciones ni de tiempo y aun así retorna una jugada
+// legal, con uno o varios workers
+func TestSearchContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	state := openingBoard()
+	for _, workers := range []int{1, 2} {
+		m := NewMCTS(1, 1<<30, 4, 60)
+		m.Workers = workers
+		start := time.Now()
+		move, ok := m.SearchContext(ctx, state, 'B')
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("%d workers: SearchContext tardó %v con el contexto cancelado", workers, elapsed)
+		}
+		if !ok || !board.IsValidMove(state, move[0], move[1]) {
+			t.Errorf("%d workers: SearchContext = %v, %v; want una jugada legal", workers, move, ok)
+		}
+	}
+}
+
+// TestAdvanceKeepsSubtree: tras Advance la raíz retenida es el hijo jugado,
+// con sus visitas y victorias, y la siguiente búsqueda sigue sumando sobre él;
+// un movimiento sin explorar descarta la raíz
+func TestAdvanceKeepsSubtree(t *testing.T) {
+	m := NewMCTS(1, 300, 2, 60)
+	m.ReuseTree = true
+	m.RolloutPolicy = nil
+	state := openingBoard()
+	if _, ok := m.Search(state, 'B'); !ok {
+		t.Fatal("Search no devolvió movimiento")
+	}
+
+	var played *edge
+	for i := range m.root.children {
+		if e := &m.root.children[i]; played == nil || e.node.visits > played.node.visits {
+			played = e
+		}
+	}
+	child, visits, wins := played.node, played.node.visits, played.node.wins
+	if visits < 2 {
+		t.Fatalf("el hijo más visitado tiene %d visitas", visits)
+	}
+
+	// Las piedras del turno en otro orden son el mismo movimiento
+	m.Advance(board.Move{played.move[1], played.move[0]})
+	if m.root != child {
+		t.Fatal("Advance no promovió el hijo jugado a raíz")
+	}
+	if m.root.visits != visits || m.root.wins != wins {
+		t.Errorf("raíz tras Advance: %d visitas y %.1f victorias, want %d y %.1f", m.root.visits, m.root.wins, visits, wins)
+	}
+
+	after := applied(state, played.move, 'B')
+	if _, ok := m.Search(after, 'W'); !ok {
+		t.Fatal("Search tras Advance no devolvió movimiento")
+	}
+	if m.root != child || m.root.visits <= visits {
+		t.Errorf("la búsqueda no reutilizó el subárbol: raíz %p (%d visitas), want %p con más de %d", m.root, m.root.visits, child, visits)
+	}
+
+	m.Advance(board.Move{{Row: 0, Col: 0}, {Row: 18, Col: 18}})
+	if m.root != nil {
+		t.Error("un movimiento sin explorar no descartó la raíz")
+	}
+}
+
+// TestTemperatureSelection: con Temperature = 0 getBestMove elige siempre el
+// hijo más visitado; con una temperatura alta y semilla fija a veces elige
+// otro, siempre entre los hijos de la raíz
+func TestTemperatureSelection(t *testing.T) {
+	p := func(r, c int) board.Position { return board.Position{Row: r, Col: c} }
+	state := openingBoard()
+	root := &Node{board: state, player: 'W', visits: 100}
+	for i, visits := range []int{20, 50, 30} {
+		move := board.Move{p(3, 3+4*i), p(15, 3+4*i)}
+		root.children = append(root.children, edge{move: move, node: &Node{board: applied(state, move, 'B'), player: 'B', visits: visits, wins: float64(visits) / 2}})
+	}
+	mostVisited := root.children[1].move
+
+	greedy := NewMCTS(1, 0, 0, 1)
+	for i := 0; i < 20; i++ {
+		if move, ok := greedy.getBestMove(root); !ok || move != mostVisited {
+			t.Fatalf("Temperature 0: getBestMove = %v, %v; want %v", move, ok, mostVisited)
+		}
+	}
+
+	hot := NewMCTS(1, 0, 0, 1)
+	hot.Temperature = 5
+	others := 0
+	for i := 0; i < 50; i++ {
+		move, ok := hot.getBestMove(root)
+		if !ok {
+			t.Fatal("Temperature 5: getBestMove no devolvió movimiento")
+		}
+		known := false
+		for _, e := range root.children {
+			known = known || e.move == move
+		}
+		if !known {
+			t.Fatalf("Temperature 5: %v no es un hijo de la raíz", move)
+		}
+		if move != mostVisited {
+			others++
+		}
+	}
+	if others == 0 {
+		t.Error("Temperature 5: en 50 elecciones siempre salió el más visitado")
+	}
+}
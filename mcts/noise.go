@@ -0,0 +1,60 @@
+package mcts
+
+import (
+	"math"
+
+	"connect6/board"
+)
+
+// dirichletAlpha es la concentración del ruido de raíz; valores < 1 concentran
+// el ruido en pocos movimientos, lo que produce aperturas más variadas
+const dirichletAlpha = 0.3
+
+// dirichletNoise muestrea Dir(alpha) sobre todos los movimientos de la raíz
+// Retorna: Ruido por movimiento (normalizado con normalizeMove) escalado por
+// el número de movimientos, de modo que su media es 1 y es comparable con UCB
+func (m *MCTS) dirichletNoise(root *Node) map[board.Move]float64 {
+	var moves []board.Move
+	for _, e := range root.children {
+		moves = append(moves, e.move)
+	}
+	moves = append(moves, root.untriedMoves...)
+	if len(moves) == 0 {
+		return nil
+	}
+
+	samples := make([]float64, len(moves))
+	sum := 0.0
+	for i := range samples {
+		samples[i] = m.gammaSample(dirichletAlpha)
+		sum += samples[i]
+	}
+
+	noise := make(map[board.Move]float64, len(moves))
+	for i, mv := range moves {
+		noise[normalizeMove(mv)] = samples[i] / sum * float64(len(moves))
+	}
+	return noise
+}
+
+// gammaSample muestrea Gamma(alpha, 1) con el método de Marsaglia-Tsang
+// Para alpha < 1 se usa Gamma(alpha+1) * U^(1/alpha)
+func (m *MCTS) gammaSample(alpha float64) float64 {
+	if alpha < 1 {
+		return m.gammaSample(alpha+1) * math.Pow(m.rng.Float64(), 1/alpha)
+	}
+	d := alpha - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := m.rng.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := m.rng.Float64()
+		if math.Log(u) < 0.5*x*x+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
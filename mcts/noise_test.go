@@ -0,0 +1,77 @@
+package mcts
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"connect6/board"
+)
+
+// noiseRoot es una raíz con tres hijos de estadísticas idénticas: sin ruido
+// todos tienen el mismo valor UCB y se elige el primero
+func noiseRoot() *Node {
+	p := func(r, c int) board.Position { return board.Position{Row: r, Col: c} }
+	root := &Node{board: openingBoard(), player: 'W', visits: 30}
+	for col := 0; col < 3; col++ {
+		move := board.Move{p(0, col), p(1, col)}
+		root.children = append(root.children, edge{move: move, node: &Node{visits: 10, wins: 5}})
+	}
+	return root
+}
+
+// TestRootNoiseChangesPriors: con la misma semilla el ruido de Dirichlet es el
+// mismo, cambia el valor de los hijos de la raíz y decide entre hijos iguales;
+// con RootNoise = 0 no hay ruido y la selección no cambia
+func TestRootNoiseChangesPriors(t *testing.T) {
+	root := noiseRoot()
+
+	off := NewMCTS(4, 0, 0, 1)
+	off.prepareRoot(root)
+	if off.rootNoise != nil {
+		t.Fatalf("RootNoise 0 generó ruido: %v", off.rootNoise)
+	}
+	if got := off.ucbSelect(root, true); got != root.children[0].node {
+		t.Error("sin ruido, entre hijos iguales no se eligió el primero")
+	}
+
+	on := NewMCTS(4, 0, 0, 1)
+	on.RootNoise = 0.5
+	on.prepareRoot(root)
+	if len(on.rootNoise) != len(root.children) {
+		t.Fatalf("ruido para %d movimientos, want %d", len(on.rootNoise), len(root.children))
+	}
+	sum, best := 0.0, 0
+	for i, e := range root.children {
+		n := on.rootNoise[normalizeMove(e.move)]
+		sum += n
+		if n > on.rootNoise[normalizeMove(root.children[best].move)] {
+			best = i
+		}
+	}
+	if math.Abs(sum-float64(len(root.children))) > 1e-9 {
+		t.Errorf("el ruido suma %v, want %d (media 1)", sum, len(root.children))
+	}
+	if best == 0 {
+		t.Fatal("el mayor ruido cae en el primer hijo: la prueba no distingue la selección")
+	}
+	if got := on.ucbSelect(root, true); got != root.children[best].node {
+		t.Errorf("con ruido se eligió %p, want el hijo %d (mayor ruido)", got, best)
+	}
+	if got := on.ucbSelect(root, false); got != root.children[0].node {
+		t.Error("el ruido se aplicó fuera de la raíz")
+	}
+
+	again := NewMCTS(4, 0, 0, 1)
+	again.RootNoise = 0.5
+	again.prepareRoot(noiseRoot())
+	if !reflect.DeepEqual(again.rootNoise, on.rootNoise) {
+		t.Error("la misma semilla dio otro ruido")
+	}
+	other := NewMCTS(5, 0, 0, 1)
+	other.RootNoise = 0.5
+	other.prepareRoot(noiseRoot())
+	if reflect.DeepEqual(other.rootNoise, on.rootNoise) {
+		t.Error("otra semilla dio el mismo ruido")
+	}
+}
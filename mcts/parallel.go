@@ -0,0 +1,149 @@
+package mcts
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"connect6/board"
+)
+
+// searchParallel ejecuta m.Workers árboles independientes en paralelo (root
+// parallelization) y combina las visitas/victorias de los hijos de la raíz
+// Cada worker tiene su propio generador, tabla de transposición y ruido de raíz;
+// el árbol no se retiene entre búsquedas en este modo
+func (m *MCTS) searchParallel(ctx context.Context, state board.Board, currentPlayer rune) *Node {
+	roots := make([]*Node, m.Workers)
+	// Presupuesto común: entre todos los árboles se hacen m.Iterations simulaciones
+	left := int64(m.Iterations)
+	if m.rng == nil {
+		m.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	// Semillas de los workers derivadas del generador principal (reproducibles)
+	seeds := make([]int64, m.Workers)
+	for i := range seeds {
+		seeds[i] = m.rng.Int63()
+	}
+
+	var wg sync.WaitGroup
+	for i := range roots {
+		w := *m
+		w.Workers = 1
+		w.root = nil
+		w.rng = rand.New(rand.NewSource(seeds[i]))
+		roots[i] = newNode(m.rules(), state, board.SwitchPlayer(currentPlayer))
+		w.prepareRoot(roots[i])
+
+		wg.Add(1)
+		go func(w MCTS, root *Node) {
+			defer wg.Done()
+			w.iterate(ctx, root, &left)
+		}(w, roots[i])
+	}
+	wg.Wait()
+
+	return mergeRoots(roots)
+}
+
+// mergeRoots suma las estadísticas de los hijos de varias raíces por movimiento
+// Retorna: Una raíz nueva cuyos hijos acumulan visits/wins de todos los árboles
+func mergeRoots(roots []*Node) *Node {
+	// Solo se leen las estadísticas de la raíz combinada: sus nodos no generan movimientos
+	merged := &Node{board: roots[0].board, player: roots[0].player}
+	byMove := make(map[board.Move]*Node)
+	for _, root := range roots {
+		merged.visits += root.visits
+		merged.wins += root.wins
+		for _, e := range root.children {
+			key := normalizeMove(e.move)
+			acc, ok := byMove[key]
+			if !ok {
+				acc = &Node{board: e.node.board, player: e.node.player}
+				byMove[key] = acc
+				merged.children = append(merged.children, edge{move: e.move, node: acc})
+			}
+			acc.visits += e.node.visits
+			acc.wins += e.node.wins
+		}
+	}
+	return merged
+}
+
+// normalizeMove ordena las dos piedras para que {a,b} y {b,a} coincidan
+func normalizeMove(m board.Move) board.Move {
+	if m[1] == board.NoPosition {
+		return m
+	}
+	if m[1].Row < m[0].Row || (m[1].Row == m[0].Row && m[1].Col < m[0].Col) {
+		return board.Move{m[1], m[0]}
+	}
+	return m
+}
+
+// iterateShared reparte m.Iterations entre m.Workers goroutines sobre el mismo árbol
+// La selección, la expansión y la retropropagación se hacen bajo un mutex; la
+// simulación (lo más costoso) corre en paralelo con el generador de cada worker
+func (m *MCTS) iterateShared(ctx context.Context, root *Node) {
+	left := int64(m.Iterations)
+	seeds := make([]int64, m.Workers)
+	for i := range seeds {
+		seeds[i] = m.rng.Int63()
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	done := 0 // simulaciones terminadas entre todos los workers (bajo 'mu')
+	for i := 0; i < m.Workers; i++ {
+		w := *m
+		w.rng = rand.New(rand.NewSource(seeds[i]))
+
+		wg.Add(1)
+		go func(w MCTS) {
+			defer wg.Done()
+			for ctx.Err() == nil && takeIteration(&left) {
+				mu.Lock()
+				if w.confident(root, done) {
+					mu.Unlock()
+					return
+				}
+				path := w.selectNode(root)
+				node := path[len(path)-1]
+				expanded := w.expand(node)
+				if expanded != node {
+					path = append(path, expanded)
+				}
+				addVirtualLoss(path, w.VirtualLoss)
+				mu.Unlock()
+
+				result, final := w.rollout(expanded)
+
+				mu.Lock()
+				addVirtualLoss(path, -w.VirtualLoss)
+				w.backpropagate(path, result)
+				if w.RAVE > 0 {
+					w.backpropagateRAVE(path, final, result)
+				}
+				done++
+				mu.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+// takeIteration reserva una simulación del presupuesto compartido 'left'
+// Retorna: false si ya se agotó (lo reservado por cada worker nunca suma más
+// que el presupuesto inicial, sin importar cómo se repartan)
+func takeIteration(left *int64) bool {
+	return atomic.AddInt64(left, -1) >= 0
+}
+
+// addVirtualLoss suma 'loss' visitas (sin victorias) a cada nodo de 'path'
+// Con 'loss' negativo deshace una pérdida virtual previa
+func addVirtualLoss(path []*Node, loss int) {
+	for _, node := range path {
+		node.visits += loss
+	}
+}
@@ -0,0 +1,99 @@
+package mcts
+
+import (
+	"context"
+	"testing"
+
+	"connect6/board"
+)
+
+// TestParallelSearchReturnsLegalMove busca con cuatro workers en ambos modos
+// (árboles independientes y árbol compartido); pensado para correr con -race
+func TestParallelSearchReturnsLegalMove(t *testing.T) {
+	state := openingBoard()
+	for _, shared := range []bool{false, true} {
+		m := NewMCTS(1, 200, 4, 60)
+		m.Workers = 4
+		m.SharedTree = shared
+		move, ok := m.Search(state, 'B')
+		if !ok {
+			t.Fatalf("shared=%v: Search no devolvió movimiento", shared)
+		}
+		next := state
+		if err := board.ApplyMoveChecked(&next, move, 'B'); err != nil {
+			t.Errorf("shared=%v: movimiento ilegal %v: %v", shared, move, err)
+		}
+	}
+}
+
+// TestParallelSearchKeepsIterationBudget comprueba que los workers hacen entre
+// todos exactamente m.Iterations simulaciones, aunque no sea múltiplo de Workers
+func TestParallelSearchKeepsIterationBudget(t *testing.T) {
+	for _, shared := range []bool{false, true} {
+		m := NewMCTS(1, 10, 4, 60)
+		m.Workers = 4
+		m.SharedTree = shared
+		_, info, ok := m.SearchWithInfo(openingBoard(), 'B')
+		if !ok {
+			t.Fatalf("shared=%v: Search no devolvió movimiento", shared)
+		}
+		if info.Simulations != m.Iterations {
+			t.Errorf("shared=%v: %d simulaciones, want %d", shared, info.Simulations, m.Iterations)
+		}
+	}
+}
+
+// TestVirtualLossDiversifiesSelection: con la pérdida virtual de un camino
+// todavía pendiente, la siguiente selección baja por otro hijo
+func TestVirtualLossDiversifiesSelection(t *testing.T) {
+	p := func(r, c int) board.Position { return board.Position{Row: r, Col: c} }
+	m := NewMCTS(1, 0, 0, 1)
+	root := NewNode(openingBoard(), 'W')
+	m.prepareRoot(root)
+	root.untriedMoves = []board.Move{{p(0, 0), p(0, 1)}, {p(5, 5), p(5, 6)}, {p(18, 17), p(18, 18)}}
+	for len(root.untriedMoves) > 0 {
+		child := m.expand(root)
+		child.untriedMoves = nil
+		m.backpropagate([]*Node{root, child}, 0.5)
+	}
+
+	first := m.selectNode(root)
+	addVirtualLoss(first, 3)
+	second := m.selectNode(root)
+	if first[1] == second[1] {
+		t.Error("con pérdida virtual la segunda selección repitió el mismo hijo")
+	}
+	addVirtualLoss(first, -3)
+	if again := m.selectNode(root); again[1] != first[1] {
+		t.Error("al retirar la pérdida virtual la selección no volvió al primer hijo")
+	}
+}
+
+// TestParallelRootsUseEngineRules: con Connect5 (WinLength 5) el tres negro tapado
+// por un lado se completa con dos piedras; cada árbol de los workers genera y
+// ordena sus movimientos con m.Rules, así que lo primero que expanden es esa victoria
+func TestParallelRootsUseEngineRules(t *testing.T) {
+	var state board.Board
+	for c := 6; c <= 8; c++ {
+		state[9][c] = 'B'
+	}
+	state[9][5] = 'W'
+	state[2][2], state[16][16] = 'W', 'W'
+
+	m := NewMCTS(1, 2, 2, 60)
+	m.Workers = 2
+	m.Rules = board.Rules{WinLength: 5}
+	m.CriticalBlockLen = 0
+	root := m.searchTree(context.Background(), state, 'B')
+
+	if len(root.children) != 1 {
+		t.Fatalf("%d hijos en la raíz combinada, want 1 (la victoria de Connect5)", len(root.children))
+	}
+	e := root.children[0]
+	after := state
+	board.ApplyMove(&after, e.move, 'B')
+	if !m.Rules.CheckWin(after, 'B') || e.node.visits != 2 || e.node.wins != 2 {
+		t.Errorf("hijo %v con %d visitas y %.1f victorias; want la victoria en las 2 simulaciones",
+			e.move, e.node.visits, e.node.wins)
+	}
+}
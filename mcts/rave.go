@@ -0,0 +1,55 @@
+package mcts
+
+import (
+	"math"
+
+	"connect6/board"
+)
+
+// backpropagateRAVE actualiza las estadísticas AMAF tras una simulación
+// Para cada nodo del camino, cada arista cuyo hijo tiene un jugador que colocó
+// alguna de las piedras del movimiento en lo que quedó de simulación (libre en
+// el nodo, suya en 'final') suma una visita AMAF y el resultado visto desde ese jugador
+// Parámetros:
+// - path: Camino de la raíz a la hoja simulada
+// - final: Tablero al terminar la simulación
+// - result: Resultado desde el jugador de la hoja (como en backpropagate)
+func (m *MCTS) backpropagateRAVE(path []*Node, final board.Board, result float64) {
+	leafPlayer := path[len(path)-1].player
+	for _, node := range path {
+		for i := range node.children {
+			e := &node.children[i]
+			if !playedLater(node.board, final, e.move, e.node.player) {
+				continue
+			}
+			e.raveVisits++
+			if e.node.player == leafPlayer {
+				e.raveWins += result
+			} else {
+				e.raveWins += 1 - result
+			}
+		}
+	}
+}
+
+// playedLater indica si 'player' ocupó alguna piedra de 'move' entre 'before' y 'final'
+func playedLater(before, final board.Board, move board.Move, player rune) bool {
+	for _, p := range move {
+		if p != board.NoPosition && before[p.Row][p.Col] == '\x00' && final[p.Row][p.Col] == player {
+			return true
+		}
+	}
+	return false
+}
+
+// raveBlend mezcla el valor de explotación 'exploit' del hijo de 'e' con el valor AMAF de la arista
+// Retorna: (1-beta)*exploit + beta*AMAF con beta = sqrt(RAVE / (3*visits + RAVE)),
+// o 'exploit' sin cambios si RAVE está desactivado o la arista no tiene visitas AMAF
+func (m *MCTS) raveBlend(e *edge, exploit float64) float64 {
+	if m.RAVE <= 0 || e.raveVisits == 0 {
+		return exploit
+	}
+	beta := math.Sqrt(m.RAVE / (3*float64(e.node.visits) + m.RAVE))
+	amaf := e.raveWins / float64(e.raveVisits)
+	return (1-beta)*exploit + beta*amaf
+}
@@ -0,0 +1,83 @@
+package mcts
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"connect6/board"
+)
+
+// raveProbeLimit es el máximo de iteraciones que espera iterationsToFind
+const raveProbeLimit = 300
+
+// iterationsToFind cuenta las iteraciones hasta que el hijo más visitado de la
+// raíz contiene 'key' (raveProbeLimit si no llega)
+// La raíz se limita a 'candidates' barajados con 'seed', para que el orden
+// heurístico no decida por sí solo
+func iterationsToFind(b board.Board, candidates []board.Move, key board.Position, rave float64, seed int64) int {
+	m := NewMCTS(seed, 0, 4, 60)
+	m.RolloutPolicy = nil
+	m.CriticalBlockLen = 0
+	m.RAVE = rave
+
+	root := NewNode(b, 'W')
+	root.untriedMoves = append([]board.Move(nil), candidates...)
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(root.untriedMoves), func(i, j int) {
+		root.untriedMoves[i], root.untriedMoves[j] = root.untriedMoves[j], root.untriedMoves[i]
+	})
+
+	for i := 1; i <= raveProbeLimit; i++ {
+		one := int64(1)
+		m.iterate(context.Background(), root, &one)
+		best := root.children[0]
+		for _, e := range root.children {
+			if e.node.visits > best.node.visits {
+				best = e
+			}
+		}
+		if best.move[0] == key || best.move[1] == key {
+			return i
+		}
+	}
+	return raveProbeLimit
+}
+
+// TestRAVEFindsBestMoveSooner: con un tres abierto de las negras, el único candidato
+// que lo alarga es el mejor; sumando varias semillas, RAVE lo hace el más visitado
+// en menos iteraciones que UCT sin RAVE
+func TestRAVEFindsBestMoveSooner(t *testing.T) {
+	var b board.Board
+	for c := 6; c <= 8; c++ {
+		b[9][c] = 'B'
+	}
+	for _, p := range []board.Position{{Row: 4, Col: 4}, {Row: 4, Col: 14}, {Row: 14, Col: 4}, {Row: 14, Col: 14}} {
+		b[p.Row][p.Col] = 'W'
+	}
+	key := board.Position{Row: 9, Col: 9}
+
+	// Un candidato con 'key' y 15 que no tocan la fila del tres
+	var candidates []board.Move
+	withKey := false
+	for _, move := range NewNode(b, 'W').untriedMoves {
+		switch {
+		case move[0] == key || move[1] == key:
+			if !withKey {
+				candidates = append(candidates, move)
+				withKey = true
+			}
+		case move[0].Row != 9 && move[1].Row != 9 && len(candidates) < 15:
+			candidates = append(candidates, move)
+		}
+	}
+
+	plain, rave := 0, 0
+	for seed := int64(1); seed <= 16; seed++ {
+		plain += iterationsToFind(b, candidates, key, 0, seed)
+		rave += iterationsToFind(b, candidates, key, 100, seed)
+	}
+	if rave >= plain {
+		t.Errorf("con RAVE %d iteraciones en total, sin RAVE %d: RAVE debería necesitar menos", rave, plain)
+	}
+}
@@ -0,0 +1,66 @@
+package mcts
+
+import (
+	"math"
+	"math/rand"
+
+	"connect6/board"
+)
+
+// RandomRollout elige uniformemente al azar entre los movimientos de GenerateSmartMoves
+// con las reglas por defecto; es lo que hace la simulación con RolloutPolicy = nil
+// y las reglas por defecto. Con otras reglas (MCTS.Rules) usar RandomRolloutFor
+func RandomRollout(b board.Board, player rune, rng *rand.Rand) board.Move {
+	return randomRollout(board.DefaultRules, b, rng)
+}
+
+// RandomRolloutFor retorna la política de RandomRollout con las reglas 'rules'
+// (p.ej. engine.RolloutPolicy = RandomRolloutFor(engine.Rules))
+func RandomRolloutFor(rules board.Rules) func(b board.Board, player rune, rng *rand.Rand) board.Move {
+	return func(b board.Board, _ rune, rng *rand.Rand) board.Move {
+		return randomRollout(rules, b, rng)
+	}
+}
+
+// randomRollout sortea uno de los movimientos que genera 'rules' (Move{} si no hay)
+func randomRollout(rules board.Rules, b board.Board, rng *rand.Rand) board.Move {
+	moves := rules.GenerateSmartMoves(b)
+	if len(moves) == 0 {
+		return board.Move{}
+	}
+	return moves[rng.Intn(len(moves))]
+}
+
+// GreedyRollout elige el movimiento que maximiza EvaluateBoard para 'player',
+// con las reglas por defecto; con otras reglas usar GreedyRolloutFor
+func GreedyRollout(b board.Board, player rune, _ *rand.Rand) board.Move {
+	return greedyRollout(board.DefaultRules, b, player)
+}
+
+// GreedyRolloutFor retorna la política de GreedyRollout con las reglas 'rules'
+func GreedyRolloutFor(rules board.Rules) func(b board.Board, player rune, rng *rand.Rand) board.Move {
+	return func(b board.Board, player rune, _ *rand.Rand) board.Move {
+		return greedyRollout(rules, b, player)
+	}
+}
+
+// greedyRollout genera y evalúa con 'rules' (Move{} si no hay movimientos)
+func greedyRollout(rules board.Rules, b board.Board, player rune) board.Move {
+	moves := rules.GenerateSmartMoves(b)
+	if len(moves) == 0 {
+		return board.Move{}
+	}
+
+	best := moves[0]
+	bestScore := -math.MaxFloat64
+	for _, mv := range moves {
+		board.ApplyMove(&b, mv, player)
+		sc := rules.EvaluateBoard(b, player)
+		board.UnapplyMove(&b, mv)
+		if sc > bestScore {
+			bestScore = sc
+			best = mv
+		}
+	}
+	return best
+}
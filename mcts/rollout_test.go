@@ -0,0 +1,164 @@
+package mcts
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"connect6/board"
+)
+
+// TestDefaultRolloutIsUniformRandom comprueba que sin RolloutPolicy la simulación
+// elige al azar entre los movimientos generados: con semillas distintas, las
+// primeras jugadas simuladas no son siempre la misma
+func TestDefaultRolloutIsUniformRandom(t *testing.T) {
+	state := openingBoard()
+	first := make(map[board.Move]bool)
+	for seed := int64(1); seed <= 10; seed++ {
+		m := NewMCTS(seed, 1, 1, 1)
+		m.EvalCacheSize = 0
+		node := NewNode(state, 'W')
+		_, final := m.rollout(node)
+		for _, move := range board.GenerateSmartMoves(state) {
+			if final[move[0].Row][move[0].Col] == 'B' && final[move[1].Row][move[1].Col] == 'B' {
+				first[move] = true
+			}
+		}
+	}
+	if len(first) < 2 {
+		t.Errorf("la simulación por defecto jugó siempre %v; se esperaba una elección al azar", first)
+	}
+}
+
+// TestGreedyRolloutPicksBestEvaluation comprueba que GreedyRollout elige el
+// movimiento que maximiza EvaluateBoard para quien mueve
+func TestGreedyRolloutPicksBestEvaluation(t *testing.T) {
+	state := openingBoard()
+	move := GreedyRollout(state, 'B', rand.New(rand.NewSource(1)))
+	after := state
+	board.ApplyMove(&after, move, 'B')
+	best := board.EvaluateBoard(after, 'B')
+	for _, mv := range board.GenerateSmartMoves(state) {
+		other := state
+		board.ApplyMove(&other, mv, 'B')
+		if eval := board.EvaluateBoard(other, 'B'); eval > best {
+			t.Fatalf("GreedyRollout eligió %v (%.0f) pero %v vale %.0f", move, best, mv, eval)
+		}
+	}
+}
+
+// selfPlayTurns es el límite de turnos de cada partida de BenchmarkGreedyVsRandom;
+// al alcanzarlo gana quien tenga mejor evaluación
+const selfPlayTurns = 12
+
+// BenchmarkGreedyVsRandom enfrenta un motor con GreedyRollout contra uno con la
+// simulación al azar por defecto, alternando quién abre, y reporta la
+// proporción de partidas que gana el voraz ("greedy-win-rate")
+func BenchmarkGreedyVsRandom(b *testing.B) {
+	greedyWins := 0
+	for i := 0; i < b.N; i++ {
+		greedy := NewMCTS(int64(i), 20, 4, 60)
+		greedy.RolloutPolicy = GreedyRollout
+		random := NewMCTS(int64(i), 20, 4, 60)
+
+		greedyPiece := 'B'
+		if i%2 == 1 {
+			greedyPiece = 'W'
+		}
+		if playSelfPlayGame(greedy, random, greedyPiece) == greedyPiece {
+			greedyWins++
+		}
+	}
+	b.ReportMetric(float64(greedyWins)/float64(b.N), "greedy-win-rate")
+}
+
+// playSelfPlayGame juega una partida entre 'a' (con las fichas 'aPiece') y 'b'
+// Retorna: El ganador, o al llegar a selfPlayTurns quien tenga mejor evaluación
+func playSelfPlayGame(a, b *MCTS, aPiece rune) rune {
+	var state board.Board
+	player := 'B'
+	for turn := 0; turn < selfPlayTurns; turn++ {
+		engine := b
+		if player == aPiece {
+			engine = a
+		}
+		move, ok := engine.Search(state, player)
+		if !ok {
+			return ' '
+		}
+		board.ApplyMove(&state, move, player)
+		if over, winner := board.IsTerminal(state); over {
+			return winner
+		}
+		player = board.SwitchPlayer(player)
+	}
+	if board.EvaluateBoard(state, aPiece) > 0 {
+		return aPiece
+	}
+	return board.SwitchPlayer(aPiece)
+}
+
+// TestRolloutEvaluationIsGraded: sin ganador la simulación puntúa la posición
+// con una logística que distingue una ventaja leve de una decisiva
+func TestRolloutEvaluationIsGraded(t *testing.T) {
+	m := NewMCTS(1, 0, 0, 1) // MaxDepth 0: se evalúa la posición del nodo
+	value := func(b board.Board) float64 {
+		v, _ := m.rollout(NewNode(b, 'B'))
+		return v
+	}
+
+	var three, five board.Board
+	for c := 5; c <= 7; c++ {
+		three[9][c] = 'B'
+	}
+	for c := 5; c <= 9; c++ {
+		five[9][c] = 'B'
+	}
+	three[0][0], five[0][0] = 'W', 'W'
+
+	mild, strong := value(three), value(five)
+	if mild <= 0.5 || mild >= 0.75 {
+		t.Errorf("tres abierto: %v, want en (0.5, 0.75)", mild)
+	}
+	if strong < 0.9 || strong >= 1 {
+		t.Errorf("cinco abierto: %v, want en [0.9, 1)", strong)
+	}
+	if strong <= mild {
+		t.Errorf("la ventaja decisiva (%v) no puntúa más que la leve (%v)", strong, mild)
+	}
+}
+
+// TestRandomPolicyMatchesDefaultRollout: la política RandomRollout consume el
+// generador igual que la simulación sin política, así que con la misma semilla
+// la búsqueda da exactamente las mismas estadísticas
+func TestRandomPolicyMatchesDefaultRollout(t *testing.T) {
+	state := openingBoard()
+	search := func(policy func(board.Board, rune, *rand.Rand) board.Move) []Candidate {
+		m := NewMCTS(7, 60, 3, 60)
+		m.RolloutPolicy = policy
+		return m.Analyze(state, 'B', 5)
+	}
+	if plain, random := search(nil), search(RandomRollout); !reflect.DeepEqual(plain, random) {
+		t.Errorf("sin política %v\ncon RandomRollout %v", plain, random)
+	}
+}
+
+// TestRolloutForUsesRules: las políticas con reglas generan con ellas; con un
+// tope de tres movimientos solo proponen uno de esos tres
+func TestRolloutForUsesRules(t *testing.T) {
+	state := openingBoard()
+	rules := board.DefaultRules.WithMoveCaps(3, 3)
+	allowed := map[board.Move]bool{}
+	for _, move := range rules.GenerateSmartMoves(state) {
+		allowed[move] = true
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		if move := RandomRolloutFor(rules)(state, 'B', rng); !allowed[move] {
+			t.Fatalf("RandomRolloutFor propuso %v, fuera de %v", move, allowed)
+		}
+	}
+	if move := GreedyRolloutFor(rules)(state, 'B', rng); !allowed[move] {
+		t.Errorf("GreedyRolloutFor propuso %v, fuera de %v", move, allowed)
+	}
+}
@@ -0,0 +1,14 @@
+package mcts
+
+import (
+	"math/rand"
+)
+
+// Seed reinicia el generador aleatorio del motor
+// Parámetros:
+// - seed: Nueva semilla (misma semilla y tablero => misma búsqueda)
+// También descarta el árbol retenido, que dependía del generador anterior
+func (m *MCTS) Seed(seed int64) {
+	m.rng = rand.New(rand.NewSource(seed))
+	m.root = nil
+}
@@ -0,0 +1,34 @@
+package mcts
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestSameSeedSameSearch: con la misma semilla y el mismo tablero la raíz
+// termina con las mismas estadísticas (y por tanto el mismo movimiento); con
+// otra semilla las simulaciones cambian. Seed reproduce la primera búsqueda
+func TestSameSeedSameSearch(t *testing.T) {
+	analyze := func(seed int64) (*MCTS, []Candidate) {
+		m := NewMCTS(seed, 60, 3, 60)
+		m.RolloutPolicy = RandomRollout
+		return m, m.Analyze(openingBoard(), 'B', 0)
+	}
+
+	m, first := analyze(42)
+	if len(first) == 0 {
+		t.Fatal("la búsqueda no exploró ningún movimiento")
+	}
+	if _, again := analyze(42); !reflect.DeepEqual(first, again) {
+		t.Errorf("misma semilla, búsquedas distintas:\n%v\n%v", first, again)
+	}
+	if _, other := analyze(43); reflect.DeepEqual(first, other) {
+		t.Error("las semillas 42 y 43 dieron exactamente la misma búsqueda")
+	}
+
+	m.Analyze(openingBoard(), 'B', 0) // consume el generador
+	m.Seed(42)
+	if again := m.Analyze(openingBoard(), 'B', 0); !reflect.DeepEqual(first, again) {
+		t.Errorf("tras Seed(42):\n%v\nwant\n%v", again, first)
+	}
+}
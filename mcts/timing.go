@@ -0,0 +1,72 @@
+package mcts
+
+import (
+	"context"
+	"time"
+
+	"connect6/board"
+)
+
+// Reparto del tiempo con AdaptiveTime: factor sobre TimeLimit según la fase
+const (
+	openingStones  = 8    // menos piedras que esto: apertura
+	openingFactor  = 0.25 // en la apertura casi todo es trivial
+	midgameFactor  = 1.5  // el medio juego es donde más rinde pensar
+	endgameFactor  = 0.5  // con el tablero casi lleno quedan pocas opciones
+	endgameEmpties = board.BoardSize * board.BoardSize / 4
+)
+
+// MoveTime retorna el tiempo de búsqueda que corresponde a la posición 'b'
+// Sin AdaptiveTime es TimeLimit; con AdaptiveTime, TimeLimit multiplicado por el
+// factor de la fase (apertura, medio juego o final según las piedras y casillas
+// libres). Si GameClock > 0 nunca supera la parte del reloj que corresponde a
+// esta jugada (el reloj repartido entre las jugadas propias que pueden quedar)
+// Parámetros:
+// - b: Tablero actual
+// Retorna: Duración de la búsqueda
+func (m *MCTS) MoveTime(b board.Board) time.Duration {
+	limit := time.Duration(m.TimeLimit) * time.Second
+	if !m.AdaptiveTime {
+		return limit
+	}
+
+	stones := board.StoneCount(b)
+	empties := board.BoardSize*board.BoardSize - stones
+	factor := midgameFactor
+	switch {
+	case stones < openingStones:
+		factor = openingFactor
+	case empties < endgameEmpties:
+		factor = endgameFactor
+	}
+	limit = time.Duration(float64(limit) * factor)
+
+	if m.GameClock > 0 {
+		// Cada jugador coloca 2 piedras cada dos turnos: ~empties/4 jugadas propias
+		remaining := empties / 4
+		if remaining < 1 {
+			remaining = 1
+		}
+		if share := m.GameClock / time.Duration(remaining); share < limit {
+			limit = share
+		}
+	}
+	return limit
+}
+
+// withMoveTime crea el contexto de una búsqueda limitado por MoveTime
+// El cancel retornado también descuenta del GameClock el tiempo usado
+func (m *MCTS) withMoveTime(b board.Board) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.MoveTime(b))
+	start := time.Now()
+	return ctx, func() {
+		cancel()
+		if m.GameClock > 0 {
+			m.GameClock -= time.Since(start)
+			if m.GameClock <= 0 {
+				// Reloj agotado: queda en el mínimo (0 desactivaría el límite)
+				m.GameClock = time.Millisecond
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package mcts
+
+import (
+	"testing"
+	"time"
+
+	"connect6/board"
+)
+
+// stonesBoard retorna un tablero con 'n' piedras alternadas, fila por fila
+func stonesBoard(n int) board.Board {
+	var b board.Board
+	for i := 0; i < n; i++ {
+		b[i/board.BoardSize][i%board.BoardSize] = 'B'
+		if i%2 == 1 {
+			b[i/board.BoardSize][i%board.BoardSize] = 'W'
+		}
+	}
+	return b
+}
+
+// TestMoveTimeFollowsGamePhase: sin AdaptiveTime el tiempo es fijo; con él,
+// la apertura y el final reciben menos que el medio juego, y GameClock lo limita
+func TestMoveTimeFollowsGamePhase(t *testing.T) {
+	const cells = board.BoardSize * board.BoardSize
+	opening, midgame, endgame := stonesBoard(3), stonesBoard(40), stonesBoard(cells-20)
+
+	m := NewMCTS(1, 1, 1, 4)
+	for _, b := range []board.Board{opening, midgame, endgame} {
+		if got := m.MoveTime(b); got != 4*time.Second {
+			t.Errorf("sin AdaptiveTime: %v, want 4s", got)
+		}
+	}
+
+	m.AdaptiveTime = true
+	want := map[string]time.Duration{
+		"apertura":    time.Second,     // 4s * 0.25
+		"medio juego": 6 * time.Second, // 4s * 1.5
+		"final":       2 * time.Second, // 4s * 0.5
+	}
+	for name, b := range map[string]board.Board{"apertura": opening, "medio juego": midgame, "final": endgame} {
+		if got := m.MoveTime(b); got != want[name] {
+			t.Errorf("%s: %v, want %v", name, got, want[name])
+		}
+	}
+
+	// Con 321 casillas libres quedan ~80 jugadas propias: 40 s dan 500 ms por jugada
+	m.GameClock = 40 * time.Second
+	if got := m.MoveTime(midgame); got != 500*time.Millisecond {
+		t.Errorf("con GameClock: %v, want 500ms", got)
+	}
+}
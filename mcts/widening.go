@@ -0,0 +1,46 @@
+package mcts
+
+import (
+	"math"
+	"sort"
+
+	"connect6/board"
+)
+
+// canExpand indica si 'node' puede añadir un hijo nuevo
+// Sin progressive widening (WideningC == 0) basta con que queden movimientos sin probar;
+// con él, el número de hijos se limita a max(1, WideningC * visits^WideningAlpha)
+func (m *MCTS) canExpand(node *Node) bool {
+	if len(node.untriedMoves) == 0 {
+		return false
+	}
+	if m.WideningC <= 0 {
+		return true
+	}
+	limit := m.WideningC * math.Pow(float64(node.visits), m.WideningAlpha)
+	return float64(len(node.children)) < math.Max(1, limit)
+}
+
+// orderMoves ordena 'moves' de mejor a peor para 'mover' según rules.QuickMoveScore
+// El orden es estable: ante empate se conserva el de GenerateSmartMoves
+func orderMoves(rules board.Rules, b board.Board, moves []board.Move, mover rune) []board.Move {
+	scores := make([]int, len(moves))
+	for i, move := range moves {
+		scores[i] = rules.QuickMoveScore(b, move, mover)
+	}
+	sort.Stable(byScore{moves, scores})
+	return moves
+}
+
+// byScore ordena movimientos por puntaje descendente, moviendo ambos slices a la vez
+type byScore struct {
+	moves  []board.Move
+	scores []int
+}
+
+func (s byScore) Len() int           { return len(s.moves) }
+func (s byScore) Less(i, j int) bool { return s.scores[i] > s.scores[j] }
+func (s byScore) Swap(i, j int) {
+	s.moves[i], s.moves[j] = s.moves[j], s.moves[i]
+	s.scores[i], s.scores[j] = s.scores[j], s.scores[i]
+}
@@ -0,0 +1,87 @@
+package mcts
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"connect6/board"
+)
+
+// TestFirstExpansionIsBestHeuristicMove: los movimientos sin probar quedan
+// ordenados por QuickMoveScore y el primer hijo expandido es el de mayor puntaje
+func TestFirstExpansionIsBestHeuristicMove(t *testing.T) {
+	state := openingBoard()
+	node := NewNode(state, 'W') // mueven las negras
+
+	best := -1
+	for _, move := range board.GenerateSmartMoves(state) {
+		if score := board.QuickMoveScore(state, move, 'B'); score > best {
+			best = score
+		}
+	}
+	for i := 1; i < len(node.untriedMoves); i++ {
+		prev := board.QuickMoveScore(state, node.untriedMoves[i-1], 'B')
+		if score := board.QuickMoveScore(state, node.untriedMoves[i], 'B'); score > prev {
+			t.Fatalf("untriedMoves[%d] (%d) supera al anterior (%d)", i, score, prev)
+		}
+	}
+
+	m := NewMCTS(1, 1, 1, 1)
+	child := m.expand(node)
+	if child == node || len(node.children) != 1 {
+		t.Fatal("expand no creó ningún hijo")
+	}
+	if score := board.QuickMoveScore(state, node.children[0].move, 'B'); score != best {
+		t.Errorf("primer hijo %v con puntaje %d, el mejor es %d", node.children[0].move, score, best)
+	}
+}
+
+// TestWideningBoundsChildren: con progressive widening ningún nodo del árbol
+// tiene más hijos de los que permite su número de visitas (cada hijo se añadió
+// cuando len(children) < max(1, C * visits^alpha)); sin widening la raíz se
+// abre mucho más con las mismas iteraciones
+func TestWideningBoundsChildren(t *testing.T) {
+	const iterations = 200
+	grow := func(c, alpha float64) *Node {
+		m := NewMCTS(1, 0, 2, 60)
+		m.RolloutPolicy = nil
+		m.CriticalBlockLen = 0
+		m.WideningC, m.WideningAlpha = c, alpha
+		root := NewNode(openingBoard(), 'W')
+		for i := 0; i < iterations; i++ {
+			one := int64(1)
+			m.iterate(context.Background(), root, &one)
+		}
+		return root
+	}
+
+	const c, alpha = 1.0, 0.5
+	root := grow(c, alpha)
+	var check func(node *Node, depth int)
+	check = func(node *Node, depth int) {
+		if node.visits == 0 {
+			if len(node.children) != 0 {
+				t.Errorf("profundidad %d: nodo sin visitas con %d hijos", depth, len(node.children))
+			}
+			return
+		}
+		// El último hijo se añadió antes de contar la visita de esa iteración
+		bound := math.Max(1, c*math.Pow(float64(node.visits-1), alpha))
+		if n := len(node.children); float64(n-1) >= bound {
+			t.Errorf("profundidad %d: %d hijos con %d visitas, el límite es %.2f", depth, n, node.visits, bound)
+		}
+		for _, e := range node.children {
+			check(e.node, depth+1)
+		}
+	}
+	check(root, 0)
+
+	wide := grow(0, 0)
+	if len(root.children) >= len(wide.children) {
+		t.Errorf("widening: la raíz tiene %d hijos, sin widening %d", len(root.children), len(wide.children))
+	}
+	if limit := int(math.Ceil(c * math.Sqrt(iterations))); len(root.children) > limit {
+		t.Errorf("la raíz tiene %d hijos tras %d iteraciones, want <= %d", len(root.children), iterations, limit)
+	}
+}
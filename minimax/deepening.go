@@ -0,0 +1,75 @@
+package minimax
+
+import (
+	"time"
+
+	"connect6/board"
+)
+
+// maxIterativeDepth limita SearchIterative cuando Depth es 0
+const maxIterativeDepth = 10
+
+// SearchIterative busca a profundidad 1, 2, 3... hasta agotar 'limit' o
+// m.Depth (maxIterativeDepth si es 0), o hasta demostrar una victoria
+// Cada iteración prueba primero el mejor movimiento de la anterior, lo que
+// mejora la poda; una iteración interrumpida por el tiempo se descarta
+// Parámetros:
+// - b: Tablero actual
+// - player: Jugador que mueve
+// - limit: Tiempo máximo de búsqueda
+// Retorna: El movimiento de la iteración completa más profunda, esa profundidad
+// y true; Move{}, 0 y false si no hay movimientos legales
+// La profundidad 1 no se interrumpe, así que siempre hay un resultado
+func (m *Minimax) SearchIterative(b board.Board, player rune, limit time.Duration) (move board.Move, depth int, ok bool) {
+	if win := m.Rules.FindWinningMove(b, player); win != nil {
+		return *win, 1, true
+	}
+	moves := m.orderedMoves(b, player)
+	if len(moves) == 0 {
+		return board.Move{}, 0, false
+	}
+	maxDepth := m.Depth
+	if maxDepth <= 0 {
+		maxDepth = maxIterativeDepth
+	}
+
+	m.deadline = time.Now().Add(limit)
+	m.aborted = false
+	// Las tablas de poda se conservan entre iteraciones: ahí está su ventaja
+	m.resetOrdering()
+	defer func() { m.deadline, m.aborted = time.Time{}, false }()
+
+	move = moves[0]
+	for d := 1; d <= maxDepth; d++ {
+		best, score := m.searchRoot(b, player, d, moves)
+		if m.aborted {
+			break
+		}
+		move, depth = best, d
+		if score >= winScore {
+			break // victoria forzada: más profundidad no la mejora
+		}
+		moves = withFirst(moves, best)
+	}
+	return move, depth, true
+}
+
+// timeUp indica si se agotó el tiempo de SearchIterative y lo anota en m.aborted
+func (m *Minimax) timeUp() bool {
+	if !m.aborted && !m.deadline.IsZero() && time.Now().After(m.deadline) {
+		m.aborted = true
+	}
+	return m.aborted
+}
+
+// withFirst mueve 'first' al principio de 'moves' conservando el orden del resto
+func withFirst(moves []board.Move, first board.Move) []board.Move {
+	for i, move := range moves {
+		if move == first {
+			copy(moves[1:i+1], moves[:i])
+			moves[0] = first
+			break
+		}
+	}
+	return moves
+}
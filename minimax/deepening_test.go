@@ -0,0 +1,55 @@
+package minimax
+
+import (
+	"testing"
+	"time"
+
+	"connect6/board"
+)
+
+// forcedWinInTwo: tres negras en la fila 9 y tres en la columna 9; una
+// jugada crea dos amenazas que ninguna defensa cubre, y la siguiente gana
+func forcedWinInTwo() board.Board {
+	var b board.Board
+	for _, p := range []board.Position{{Row: 9, Col: 5}, {Row: 9, Col: 6}, {Row: 9, Col: 7}, {Row: 10, Col: 9}, {Row: 11, Col: 9}, {Row: 12, Col: 9}} {
+		b[p.Row][p.Col] = 'B'
+	}
+	for _, p := range []board.Position{{Row: 0, Col: 0}, {Row: 0, Col: 1}, {Row: 18, Col: 0}, {Row: 18, Col: 1}, {Row: 18, Col: 18}, {Row: 0, Col: 18}, {Row: 17, Col: 18}} {
+		b[p.Row][p.Col] = 'W'
+	}
+	return b
+}
+
+// TestDeeperSearchFindsForcedWin: la victoria en dos jugadas propias (tres
+// turnos contando la defensa) no se ve con 1 o 2 turnos y sí con 3
+func TestDeeperSearchFindsForcedWin(t *testing.T) {
+	b := forcedWinInTwo()
+	m := New(0)
+	m.ThreatPairs = true
+	for depth := 1; depth <= 3; depth++ {
+		m.resetOrdering()
+		_, score := m.searchRoot(b, 'B', depth, m.orderedMoves(b, 'B'))
+		if won := score >= winScore; won != (depth == 3) {
+			t.Errorf("profundidad %d: valor %v, victoria demostrada = %v", depth, score, won)
+		}
+	}
+
+	move, depth, ok := m.SearchIterative(b, 'B', time.Minute)
+	if !ok || depth != 3 {
+		t.Fatalf("SearchIterative = %v, profundidad %d, %v; want la victoria a profundidad 3", move, depth, ok)
+	}
+	// Ninguna defensa sirve: las amenazas solo se cortan en sus huecos, y
+	// ocupando dos cualesquiera las negras siguen ganando
+	after := b
+	board.ApplyMove(&after, move, 'B')
+	gaps := board.FindSplitFours(after, 'B')
+	for i := range gaps {
+		for j := i + 1; j < len(gaps); j++ {
+			defended := after
+			board.ApplyMove(&defended, board.Move{gaps[i], gaps[j]}, 'W')
+			if board.FindWinningMove(defended, 'B') == nil {
+				t.Errorf("tras %v la defensa %v, %v bloquea todo", move, gaps[i], gaps[j])
+			}
+		}
+	}
+}
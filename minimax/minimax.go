@@ -0,0 +1,216 @@
+package minimax
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"connect6/board"
+)
+
+// DefaultDepth son los turnos que New busca por defecto (cada turno es un
+// Move completo de una o dos piedras)
+const DefaultDepth = 2
+
+// Topes de board.Rules.GenerateSmartMoves para Minimax: el árbol crece como
+// maxMoves^Depth, así que se generan menos movimientos que en MCTS
+const (
+	maxPairs = 30
+	maxMoves = 40
+)
+
+// winScore es el valor de una victoria; supera cualquier evaluación heurística
+const winScore = 1e9
+
+// Minimax es un motor determinista de búsqueda alfa-beta de profundidad fija
+// sobre board.GenerateSmartMoves, con board.EvaluateBoard en las hojas
+type Minimax struct {
+	// Depth son los turnos de búsqueda de Search (0 => DefaultDepth); con
+	// TimeLimit es la profundidad máxima (0 => maxIterativeDepth)
+	Depth int
+
+	// TimeLimit, en segundos, hace que Search profundice iterativamente hasta
+	// agotarlo (ver SearchIterative); 0 busca siempre a profundidad fija
+	TimeLimit int
+
+	Rules board.Rules // Reglas de generación y evaluación
+
+	// KillerHistory ordena primero los movimientos que ya causaron podas
+	// (killer moves por profundidad y tabla de historia, ver ordering.go)
+	KillerHistory bool
+
+	// ThreatPairs añade a los candidatos los pares de casillas de amenaza
+	// (ver threats.go), que los topes de generación suelen dejar fuera
+	ThreatPairs bool
+
+	deadline time.Time // fin de la búsqueda en curso (cero => sin límite)
+	aborted  bool      // se agotó el tiempo: la iteración en curso no vale
+
+	rootDepth int                   // turnos de la iteración en curso (ver ply)
+	killers   map[int][2]board.Move // por distancia a la raíz: los dos últimos movimientos que podaron
+	history   map[historyKey]int    // por jugador y movimiento: suma de depth² de sus podas
+	nodes     int                   // movimientos valorados en la última búsqueda
+}
+
+// New crea un motor de 'depth' turnos con las reglas por defecto, los topes
+// de movimientos de este paquete y KillerHistory activado
+func New(depth int) *Minimax {
+	return &Minimax{
+		Depth:         depth,
+		Rules:         board.DefaultRules.WithMoveCaps(maxPairs, maxMoves),
+		KillerHistory: true,
+	}
+}
+
+// BestMove busca el mejor movimiento de 'player' con un Minimax por defecto
+// Parámetros:
+// - b: Tablero actual
+// - player: Jugador que mueve
+// - depth: Turnos de búsqueda (1 => solo las jugadas propias)
+// Retorna: El movimiento y true, o Move{} y false si no hay movimientos legales
+func BestMove(b board.Board, player rune, depth int) (board.Move, bool) {
+	return New(depth).BestMove(b, player, depth)
+}
+
+// Search busca con m.Depth turnos, o por profundización iterativa durante
+// m.TimeLimit segundos si es mayor que 0; misma firma que mcts.Search
+func (m *Minimax) Search(b board.Board, player rune) (board.Move, bool) {
+	if m.TimeLimit > 0 {
+		move, _, ok := m.SearchIterative(b, player, time.Duration(m.TimeLimit)*time.Second)
+		return move, ok
+	}
+	depth := m.Depth
+	if depth <= 0 {
+		depth = DefaultDepth
+	}
+	return m.BestMove(b, player, depth)
+}
+
+// BestMove ejecuta la búsqueda alfa-beta desde 'b'
+// Ante empate de valor se queda con el primero según board.QuickMoveScore,
+// así que el resultado es siempre el mismo para la misma posición
+func (m *Minimax) BestMove(b board.Board, player rune, depth int) (board.Move, bool) {
+	// Los topes de generación pueden dejar fuera la victoria: se busca aparte
+	if win := m.Rules.FindWinningMove(b, player); win != nil {
+		return *win, true
+	}
+	moves := m.orderedMoves(b, player)
+	if len(moves) == 0 {
+		return board.Move{}, false
+	}
+	if depth < 1 {
+		depth = 1
+	}
+	m.resetOrdering()
+	best, _ := m.searchRoot(b, player, depth, moves)
+	return best, true
+}
+
+// searchRoot prueba 'moves' en orden con 'depth' turnos
+// Retorna: El mejor movimiento (el primero ante empate) y su valor
+func (m *Minimax) searchRoot(b board.Board, player rune, depth int, moves []board.Move) (board.Move, float64) {
+	m.rootDepth = depth
+	best := moves[0]
+	alpha := math.Inf(-1)
+	for _, move := range moves {
+		score := m.scoreMove(b, move, player, depth, alpha, math.Inf(1))
+		if score > alpha {
+			alpha = score
+			best = move
+		}
+	}
+	return best, alpha
+}
+
+// scoreMove valora 'move' de 'player' con 'depth' turnos contando este
+// Las victorias más cercanas valen más (winScore + turnos restantes)
+func (m *Minimax) scoreMove(b board.Board, move board.Move, player rune, depth int, alpha, beta float64) float64 {
+	m.nodes++
+	next := b
+	board.ApplyMove(&next, move, player)
+	if m.Rules.MoveWins(next, move, player) {
+		return winScore + float64(depth)
+	}
+	if depth == 1 {
+		return m.Rules.EvaluateBoard(next, player)
+	}
+	return -m.negamax(next, board.SwitchPlayer(player), depth-1, -beta, -alpha)
+}
+
+// negamax retorna el valor de 'b' para 'player', que mueve, dentro de (alpha, beta)
+// Sin movimientos legales la posición es tablas (0)
+func (m *Minimax) negamax(b board.Board, player rune, depth int, alpha, beta float64) float64 {
+	if m.timeUp() {
+		return 0 // el valor se descarta (ver SearchIterative)
+	}
+	if m.Rules.FindWinningMove(b, player) != nil {
+		return winScore + float64(depth)
+	}
+	moves := m.orderedMoves(b, player)
+	if len(moves) == 0 {
+		return 0
+	}
+	if m.KillerHistory {
+		m.orderByCutoffs(moves, player, depth)
+	}
+	best := math.Inf(-1)
+	for _, move := range moves {
+		if m.timeUp() {
+			return 0
+		}
+		score := m.scoreMove(b, move, player, depth, alpha, beta)
+		if score > best {
+			best = score
+		}
+		if score > alpha {
+			alpha = score
+		}
+		if alpha >= beta {
+			if m.KillerHistory {
+				m.recordCutoff(move, player, depth)
+			}
+			break // el rival ya tiene algo mejor: poda
+		}
+	}
+	return best
+}
+
+// orderedMoves genera los movimientos de 'player' de mejor a peor según
+// board.QuickMoveScore (orden estable), para que la poda corte antes
+// Si el rival amenaza ganar se añaden los pares de sus casillas críticas
+// (board.FindCriticalBlocks): el doble bloqueo suele quedar fuera de los topes
+// Con ThreatPairs se añaden también los pares de casillas de amenaza
+func (m *Minimax) orderedMoves(b board.Board, player rune) []board.Move {
+	moves := m.Rules.GenerateSmartMoves(b)
+	if m.ThreatPairs {
+		moves = append(moves, m.threatPairs(b, player)...)
+	}
+	opponent := board.SwitchPlayer(player)
+	if board.MoveStoneCount(b) == 2 && m.Rules.FindWinningMove(b, opponent) != nil {
+		blocks := board.FindCriticalBlocks(b, opponent)
+		for i := range blocks {
+			for j := i + 1; j < len(blocks); j++ {
+				moves = append(moves, board.Move{blocks[i], blocks[j]})
+			}
+		}
+	}
+	scores := make([]int, len(moves))
+	for i, move := range moves {
+		scores[i] = m.Rules.QuickMoveScore(b, move, player)
+	}
+	sort.Stable(byScore{moves, scores})
+	return moves
+}
+
+// byScore ordena movimientos por puntaje descendente, moviendo ambos slices a la vez
+type byScore struct {
+	moves  []board.Move
+	scores []int
+}
+
+func (s byScore) Len() int           { return len(s.moves) }
+func (s byScore) Less(i, j int) bool { return s.scores[i] > s.scores[j] }
+func (s byScore) Swap(i, j int) {
+	s.moves[i], s.moves[j] = s.moves[j], s.moves[i]
+	s.scores[i], s.scores[j] = s.scores[j], s.scores[i]
+}
@@ -0,0 +1,45 @@
+package minimax
+
+import (
+	"testing"
+
+	"connect6/board"
+)
+
+// fiveInRow pone cinco piedras de 'player' en la fila 'row' desde la columna 4,
+// tapadas a la izquierda por el rival: solo se completan en (row, 9)
+func fiveInRow(b *board.Board, player rune, row int) board.Position {
+	for c := 4; c <= 8; c++ {
+		b[row][c] = player
+	}
+	b[row][3] = board.SwitchPlayer(player)
+	return board.Position{Row: row, Col: 9}
+}
+
+// TestBestMoveWinsAndBlocks: minimax completa la victoria inmediata (también sin
+// el atajo de FindWinningMove, con la búsqueda alfa-beta) y, si no la tiene,
+// bloquea la del rival
+func TestBestMoveWinsAndBlocks(t *testing.T) {
+	var win board.Board
+	winAt := fiveInRow(&win, 'B', 9)
+	win[2][2], win[16][16] = 'W', 'W'
+
+	move, ok := BestMove(win, 'B', 2)
+	if !ok || (move[0] != winAt && move[1] != winAt) {
+		t.Errorf("BestMove = %v, %v; want una victoria en %v", move, ok, winAt)
+	}
+	m := New(1)
+	m.resetOrdering()
+	move, score := m.searchRoot(win, 'B', 1, m.orderedMoves(win, 'B'))
+	if score < winScore || (move[0] != winAt && move[1] != winAt) {
+		t.Errorf("searchRoot = %v con valor %v; want la victoria en %v", move, score, winAt)
+	}
+
+	var loss board.Board
+	blockAt := fiveInRow(&loss, 'W', 5)
+	loss[12][12], loss[14][2] = 'B', 'B'
+	move, ok = BestMove(loss, 'B', 2)
+	if !ok || (move[0] != blockAt && move[1] != blockAt) {
+		t.Errorf("BestMove = %v, %v; want que bloquee %v", move, ok, blockAt)
+	}
+}
@@ -0,0 +1,82 @@
+package minimax
+
+import (
+	"sort"
+
+	"connect6/board"
+)
+
+// historyKey separa la historia de cada jugador: una buena jugada de uno no
+// dice nada de la misma casilla para el otro
+type historyKey struct {
+	player rune
+	move   board.Move
+}
+
+// resetOrdering vacía las tablas de killer moves e historia y el contador de
+// nodos al empezar una búsqueda
+func (m *Minimax) resetOrdering() {
+	m.killers = make(map[int][2]board.Move)
+	m.history = make(map[historyKey]int)
+	m.nodes = 0
+}
+
+// Nodes retorna cuántos movimientos valoró la última búsqueda (sirve para
+// medir la eficacia de la poda)
+func (m *Minimax) Nodes() int {
+	return m.nodes
+}
+
+// recordCutoff anota que 'move' causó una poda con 'depth' turnos restantes:
+// pasa a ser el primer killer de su distancia a la raíz y suma depth² a la
+// historia de 'player' (las podas cerca de la raíz ahorran más)
+func (m *Minimax) recordCutoff(move board.Move, player rune, depth int) {
+	ply := m.ply(depth)
+	if k := m.killers[ply]; k[0] != move {
+		m.killers[ply] = [2]board.Move{move, k[0]}
+	}
+	m.history[historyKey{player, normalizeMove(move)}] += depth * depth
+}
+
+// orderByCutoffs reordena los 'moves' de 'player' (ya ordenados por
+// board.QuickMoveScore): primero los killers de la distancia a la raíz de un
+// nodo con 'depth' turnos restantes, luego por historia descendente; el orden
+// estable conserva QuickMoveScore entre movimientos sin datos
+func (m *Minimax) orderByCutoffs(moves []board.Move, player rune, depth int) {
+	killers := m.killers[m.ply(depth)]
+	rank := func(move board.Move) int {
+		switch move {
+		case killers[0]:
+			return 2
+		case killers[1]:
+			return 1
+		}
+		return 0
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		ri, rj := rank(moves[i]), rank(moves[j])
+		if ri != rj {
+			return ri > rj
+		}
+		return m.history[historyKey{player, normalizeMove(moves[i])}] > m.history[historyKey{player, normalizeMove(moves[j])}]
+	})
+}
+
+// ply retorna la distancia a la raíz (en turnos) de un nodo con 'depth' turnos
+// restantes en la búsqueda en curso. Los killers se guardan por ply y no por
+// profundidad restante: en la profundización iterativa un mismo nodo tiene
+// otra profundidad restante en cada iteración, pero la misma distancia a la raíz
+func (m *Minimax) ply(depth int) int {
+	return m.rootDepth - depth
+}
+
+// normalizeMove ordena las dos piedras para que {a,b} y {b,a} compartan historia
+func normalizeMove(move board.Move) board.Move {
+	if move[1] == board.NoPosition {
+		return move
+	}
+	if move[1].Row < move[0].Row || (move[1].Row == move[0].Row && move[1].Col < move[0].Col) {
+		return board.Move{move[1], move[0]}
+	}
+	return move
+}
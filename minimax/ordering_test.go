@@ -0,0 +1,82 @@
+package minimax
+
+import (
+	"testing"
+
+	"connect6/board"
+)
+
+// midgame es una posición fija de medio juego para medir la poda
+func midgame() board.Board {
+	var b board.Board
+	for _, p := range []board.Position{{Row: 9, Col: 9}, {Row: 8, Col: 10}, {Row: 10, Col: 10}, {Row: 7, Col: 8}, {Row: 11, Col: 8}} {
+		b[p.Row][p.Col] = 'B'
+	}
+	for _, p := range []board.Position{{Row: 9, Col: 10}, {Row: 10, Col: 9}, {Row: 8, Col: 8}, {Row: 10, Col: 11}} {
+		b[p.Row][p.Col] = 'W'
+	}
+	return b
+}
+
+// BenchmarkMinimaxKillerHistory busca la misma posición con y sin killer
+// moves e historia; la métrica "nodes" son los movimientos valorados
+// (Minimax.Nodes), que la mejor ordenación debe reducir
+func BenchmarkMinimaxKillerHistory(b *testing.B) {
+	state := midgame()
+	for _, on := range []bool{false, true} {
+		name := "off"
+		if on {
+			name = "on"
+		}
+		b.Run(name, func(b *testing.B) {
+			nodes := 0
+			for i := 0; i < b.N; i++ {
+				m := New(3)
+				m.KillerHistory = on
+				m.BestMove(state, 'W', 3)
+				nodes = m.Nodes()
+			}
+			b.ReportMetric(float64(nodes), "nodes")
+		})
+	}
+}
+
+// TestKillerHistoryVisitsFewerNodes: en la posición del benchmark la
+// ordenación por podas valora menos movimientos y elige lo mismo
+func TestKillerHistoryVisitsFewerNodes(t *testing.T) {
+	state := midgame()
+	plain, ordered := New(3), New(3)
+	plain.KillerHistory = false
+	want, _ := plain.BestMove(state, 'W', 3)
+	got, _ := ordered.BestMove(state, 'W', 3)
+	if ordered.Nodes() >= plain.Nodes() {
+		t.Errorf("con KillerHistory %d nodos, sin él %d", ordered.Nodes(), plain.Nodes())
+	}
+	if got != want {
+		t.Errorf("con KillerHistory eligió %v, sin él %v", got, want)
+	}
+}
+
+// TestKillersKeyedByPly: un killer anotado en una iteración se usa en la
+// siguiente a la misma distancia de la raíz, aunque la profundidad restante cambie
+func TestKillersKeyedByPly(t *testing.T) {
+	p := func(r, c int) board.Position { return board.Position{Row: r, Col: c} }
+	killer := board.Move{p(3, 3), p(3, 4)}
+	m := New(0)
+	m.resetOrdering()
+	m.rootDepth = 2
+	m.recordCutoff(killer, 'W', 1) // ply 1, profundidad restante 1
+
+	// Se ordena para las negras, sin historia: solo cuenta el killer
+	m.rootDepth = 3
+	moves := []board.Move{{p(0, 0), p(0, 1)}, killer}
+	m.orderByCutoffs(moves, 'B', 2) // ply 1, profundidad restante 2
+	if moves[0] != killer {
+		t.Errorf("el killer del ply 1 no se ordenó primero: %v", moves)
+	}
+	moves = []board.Move{{p(0, 0), p(0, 1)}, killer}
+	m.orderByCutoffs(moves, 'B', 1) // ply 2: sin killers
+	if moves[0] == killer {
+		t.Error("el killer del ply 1 se usó en el ply 2")
+	}
+}
@@ -0,0 +1,55 @@
+package minimax
+
+import (
+	"sort"
+
+	"connect6/board"
+)
+
+// maxThreatCells limita las casillas de threatPairs (se prueban todos sus pares)
+const maxThreatCells = 8
+
+// threatPairs combina de dos en dos las casillas donde alguno de los jugadores
+// formaría una cadena de m.Rules.WinLength-3 piedras o más (board.ThreatMap), las
+// maxThreatCells más largas; GenerateSmartMoves empareja casillas por cercanía
+// en el recorrido del tablero y con sus topes suele omitir estas combinaciones,
+// que son las que crean (o cortan) amenazas dobles
+func (m *Minimax) threatPairs(b board.Board, player rune) []board.Move {
+	if board.MoveStoneCount(b) == 1 {
+		return nil
+	}
+	own := board.ThreatMap(b, player)
+	opp := board.ThreatMap(b, board.SwitchPlayer(player))
+	type threatCell struct {
+		pos    board.Position
+		length int
+	}
+	var found []threatCell
+	for r := 0; r < board.BoardSize; r++ {
+		for c := 0; c < board.BoardSize; c++ {
+			length := own[r][c]
+			if opp[r][c] > length {
+				length = opp[r][c]
+			}
+			if length >= m.Rules.WinLength-3 {
+				found = append(found, threatCell{board.Position{Row: r, Col: c}, length})
+			}
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].length > found[j].length })
+	if len(found) > maxThreatCells {
+		found = found[:maxThreatCells]
+	}
+	cells := make([]board.Position, len(found))
+	for i, f := range found {
+		cells[i] = f.pos
+	}
+
+	var moves []board.Move
+	for i := range cells {
+		for j := i + 1; j < len(cells); j++ {
+			moves = append(moves, board.Move{cells[i], cells[j]})
+		}
+	}
+	return moves
+}
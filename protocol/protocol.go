@@ -0,0 +1,202 @@
+package protocol
+
+import (
+	"bufio"
+	"connect6/board"
+	"connect6/mcts"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// columnLetters son las letras de columna al estilo GTP (sin la 'I')
+const columnLetters = "ABCDEFGHJKLMNOPQRST"
+
+// Session mantiene el estado de una partida controlada por texto estilo GTP
+//
+// Comandos admitidos (uno por línea, con id numérico opcional al inicio):
+//   - play B K10 [L11]: coloca una o dos piedras del color indicado
+//   - genmove W: busca con MCTS, aplica y responde las coordenadas
+//   - showboard: dibuja el tablero
+//   - clear_board: vacía el tablero
+//   - boardsize 19: solo se acepta el tamaño fijo del tablero (board.BoardSize)
+//   - name, version, protocol_version, list_commands, quit
+//
+// Respuestas: "= respuesta" si tuvo éxito, "? error" si falló, seguidas de
+// una línea vacía. Las coordenadas van de A1 (abajo a la izquierda) a T19.
+type Session struct {
+	board  board.Board
+	engine *mcts.MCTS
+}
+
+// NewSession crea una sesión con tablero vacío que usa 'engine' para genmove
+func NewSession(engine *mcts.MCTS) *Session {
+	return &Session{engine: engine}
+}
+
+// Run lee comandos de 'r' y escribe las respuestas en 'w' hasta 'quit' o EOF
+func (s *Session) Run(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		id := ""
+		if _, err := strconv.Atoi(fields[0]); err == nil {
+			id, fields = fields[0], fields[1:]
+			if len(fields) == 0 {
+				continue
+			}
+		}
+
+		resp, err := s.Execute(fields[0], fields[1:])
+		if err != nil {
+			fmt.Fprintf(w, "?%s %s\n\n", id, err)
+		} else {
+			fmt.Fprintf(w, "=%s %s\n\n", id, resp)
+		}
+		if fields[0] == "quit" {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// Execute ejecuta un comando y retorna el texto de la respuesta
+func (s *Session) Execute(cmd string, args []string) (string, error) {
+	switch cmd {
+	case "name":
+		return "Connect6", nil
+	case "version":
+		return "1.0", nil
+	case "protocol_version":
+		return "2", nil
+	case "list_commands":
+		return strings.Join([]string{"play", "genmove", "showboard", "clear_board", "boardsize",
+			"name", "version", "protocol_version", "list_commands", "quit"}, "\n"), nil
+	case "quit":
+		return "", nil
+	case "clear_board":
+		s.board = board.Board{}
+		s.engine.ClearCache()
+		return "", nil
+	case "boardsize":
+		if len(args) != 1 {
+			return "", fmt.Errorf("syntax error")
+		}
+		if size, err := strconv.Atoi(args[0]); err != nil || size != board.BoardSize {
+			return "", fmt.Errorf("unacceptable size")
+		}
+		return "", nil
+	case "showboard":
+		return "\n" + renderBoard(s.board), nil
+	case "play":
+		return "", s.play(args)
+	case "genmove":
+		return s.genmove(args)
+	}
+	return "", fmt.Errorf("unknown command")
+}
+
+// play aplica "play <color> <coord> [coord]"
+func (s *Session) play(args []string) error {
+	if len(args) < 2 || len(args) > 3 {
+		return fmt.Errorf("syntax error")
+	}
+	player, err := parseColor(args[0])
+	if err != nil {
+		return err
+	}
+
+	move := board.Move{{}, board.NoPosition}
+	for i, arg := range args[1:] {
+		p, err := ParseVertex(arg)
+		if err != nil {
+			return err
+		}
+		move[i] = p
+	}
+	if !board.IsValidMove(s.board, move[0], move[1]) {
+		return fmt.Errorf("illegal move")
+	}
+	board.ApplyMove(&s.board, move, player)
+	return nil
+}
+
+// genmove busca el movimiento de "genmove <color>", lo aplica y lo retorna
+func (s *Session) genmove(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("syntax error")
+	}
+	player, err := parseColor(args[0])
+	if err != nil {
+		return "", err
+	}
+	if over, _ := board.IsTerminal(s.board); over {
+		return "", fmt.Errorf("game is over")
+	}
+
+	move, ok := s.engine.Search(s.board, player)
+	if !ok {
+		return "", fmt.Errorf("no legal moves")
+	}
+	board.ApplyMove(&s.board, move, player)
+
+	resp := FormatVertex(move[0])
+	if move[1] != board.NoPosition {
+		resp += " " + FormatVertex(move[1])
+	}
+	return resp, nil
+}
+
+// parseColor acepta B/W/black/white (sin distinguir mayúsculas)
+func parseColor(s string) (rune, error) {
+	switch strings.ToLower(s) {
+	case "b", "black":
+		return 'B', nil
+	case "w", "white":
+		return 'W', nil
+	}
+	return 0, fmt.Errorf("invalid color")
+}
+
+// ParseVertex convierte coordenadas GTP (p.ej. "K10") en una Position
+func ParseVertex(s string) (board.Position, error) {
+	s = strings.ToUpper(s)
+	if len(s) < 2 {
+		return board.Position{}, fmt.Errorf("invalid coordinate")
+	}
+	col := strings.IndexByte(columnLetters, s[0])
+	n, err := strconv.Atoi(s[1:])
+	if col < 0 || err != nil || n < 1 || n > board.BoardSize {
+		return board.Position{}, fmt.Errorf("invalid coordinate")
+	}
+	return board.Position{Row: board.BoardSize - n, Col: col}, nil
+}
+
+// FormatVertex convierte una Position en coordenadas GTP
+func FormatVertex(p board.Position) string {
+	return fmt.Sprintf("%c%d", columnLetters[p.Col], board.BoardSize-p.Row)
+}
+
+// renderBoard dibuja el tablero con coordenadas GTP
+func renderBoard(b board.Board) string {
+	var sb strings.Builder
+	sb.WriteString("   " + strings.Join(strings.Split(columnLetters, ""), " ") + "\n")
+	for r := 0; r < board.BoardSize; r++ {
+		fmt.Fprintf(&sb, "%2d", board.BoardSize-r)
+		for c := 0; c < board.BoardSize; c++ {
+			cell := b[r][c]
+			if cell == '\x00' {
+				cell = '.'
+			}
+			fmt.Fprintf(&sb, " %c", cell)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
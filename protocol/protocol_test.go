@@ -0,0 +1,70 @@
+package protocol
+
+import (
+	"strings"
+	"testing"
+
+	"connect6/board"
+	"connect6/mcts"
+)
+
+// TestSessionRunScript: un guion de comandos recibe una respuesta "=" o "?" por
+// comando (con su id, y una línea vacía detrás); play rechaza una casilla ocupada,
+// genmove juega dos piedras legales y quit termina sin leer el resto
+func TestSessionRunScript(t *testing.T) {
+	engine := mcts.NewMCTS(1, 20, 2, 60)
+	engine.RolloutPolicy = mcts.RandomRollout
+	s := NewSession(engine)
+
+	script := strings.Join([]string{
+		"boardsize 19",
+		"2 boardsize 13",
+		"clear_board",
+		"play B K10",
+		"play W K10 L11",
+		"3 play W L11 M12",
+		"genmove B",
+		"play B A1 Z9",
+		"quit",
+		"play B A2",
+	}, "\n")
+	var out strings.Builder
+	if err := s.Run(strings.NewReader(script), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	responses := strings.Split(strings.TrimSuffix(out.String(), "\n\n"), "\n\n")
+	want := []string{"= ", "?2 unacceptable size", "= ", "= ", "? illegal move", "=3 ", "", "? invalid coordinate", "= "}
+	if len(responses) != len(want) {
+		t.Fatalf("%d respuestas, want %d:\n%s", len(responses), len(want), out.String())
+	}
+	for i, resp := range responses {
+		if i == 6 {
+			continue // genmove: se comprueba abajo
+		}
+		if resp != want[i] {
+			t.Errorf("respuesta %d = %q, want %q", i, resp, want[i])
+		}
+	}
+
+	// genmove responde "= V1 V2" con dos casillas que quedan negras
+	fields := strings.Fields(strings.TrimPrefix(responses[6], "="))
+	if !strings.HasPrefix(responses[6], "= ") || len(fields) != 2 {
+		t.Fatalf("genmove = %q, want dos coordenadas", responses[6])
+	}
+	for _, f := range fields {
+		p, err := ParseVertex(f)
+		if err != nil || s.board[p.Row][p.Col] != 'B' {
+			t.Errorf("genmove %q: casilla sin piedra negra (%v)", f, err)
+		}
+	}
+
+	// play K10 dejó la negra en (9,9); la jugada rechazada no puso nada en L11
+	l11, _ := ParseVertex("L11")
+	if s.board[9][9] != 'B' || s.board[l11.Row][l11.Col] != 'W' {
+		t.Errorf("tablero tras play: K10=%q L11=%q", s.board[9][9], s.board[l11.Row][l11.Col])
+	}
+	if board.StoneCount(s.board) != 5 {
+		t.Errorf("%d piedras, want 5 (quit no debe ejecutar lo que sigue)", board.StoneCount(s.board))
+	}
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"connect6/board"
+	"connect6/mcts"
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// MoveRequest es el cuerpo JSON de POST /move
+type MoveRequest struct {
+	Board  string `json:"board"`  // tablero en formato board.Serialize
+	Player string `json:"player"` // "B" o "W": quién debe mover
+}
+
+// MoveResponse es la respuesta JSON de POST /move
+type MoveResponse struct {
+	Move     [][2]int `json:"move,omitempty"`   // una o dos posiciones [fila, columna]
+	Board    string   `json:"board"`            // tablero tras aplicar el movimiento
+	GameOver bool     `json:"gameOver"`         // true si hay ganador o el tablero está lleno
+	Winner   string   `json:"winner,omitempty"` // "B" o "W" si hay ganador
+}
+
+// errorResponse es el cuerpo JSON de las respuestas de error
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// handler atiende las peticiones con un único motor MCTS
+// mcts.MCTS no admite búsquedas concurrentes, por eso se serializan con 'mu'
+type handler struct {
+	mu     sync.Mutex
+	engine *mcts.MCTS
+}
+
+// NewHandler crea el http.Handler del servidor
+// Rutas:
+//   - POST /move: recibe MoveRequest y responde MoveResponse
+func NewHandler(engine *mcts.MCTS) http.Handler {
+	h := &handler{engine: engine}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/move", h.move)
+	return mux
+}
+
+// move busca el movimiento del jugador indicado y lo aplica al tablero recibido
+func (h *handler) move(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{"usa POST"})
+		return
+	}
+
+	var req MoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{"JSON inválido: " + err.Error()})
+		return
+	}
+	b, err := board.Parse(req.Board)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+		return
+	}
+	if req.Player != "B" && req.Player != "W" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{`player debe ser "B" o "W"`})
+		return
+	}
+	player := rune(req.Player[0])
+
+	// Si la partida ya terminó no se busca movimiento
+	if resp, over := gameOver(b); over {
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	h.mu.Lock()
+	move, ok := h.engine.Search(b, player)
+	h.mu.Unlock()
+	if !ok {
+		// Sin movimientos legales la partida termina en empate
+		resp, _ := gameOver(b)
+		resp.GameOver = true
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	board.ApplyMove(&b, move, player)
+	resp, _ := gameOver(b)
+	resp.Move = [][2]int{{move[0].Row, move[0].Col}}
+	if move[1] != board.NoPosition {
+		resp.Move = append(resp.Move, [2]int{move[1].Row, move[1].Col})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// gameOver construye la respuesta de estado para el tablero 'b'
+// Retorna: La respuesta y true si hay ganador o el tablero está lleno
+func gameOver(b board.Board) (MoveResponse, bool) {
+	resp := MoveResponse{Board: board.Serialize(b)}
+	if winner := board.GetWinner(b); winner != ' ' {
+		resp.GameOver = true
+		resp.Winner = string(winner)
+	} else if board.IsBoardFull(b) {
+		resp.GameOver = true
+	}
+	return resp, resp.GameOver
+}
+
+// writeJSON escribe 'v' como JSON con el código de estado indicado
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"bytes"
+	"connect6/board"
+	"connect6/mcts"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// post envía 'body' a POST /move y retorna la respuesta grabada
+func post(t *testing.T, h http.Handler, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/move", bytes.NewReader(data)))
+	return rec
+}
+
+// TestMoveWinsNearWinPosition: con cuatro negras en fila, el movimiento
+// retornado completa la línea y la respuesta indica el final de la partida
+func TestMoveWinsNearWinPosition(t *testing.T) {
+	var b board.Board
+	for c := 5; c < 9; c++ {
+		b[9][c] = 'B'
+		b[12][c] = 'W'
+	}
+	b[3][3] = 'W'
+	h := NewHandler(mcts.NewMCTS(1, 50, 4, 5))
+
+	rec := post(t, h, MoveRequest{Board: board.Serialize(b), Player: "B"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	var resp MoveResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.GameOver || resp.Winner != "B" || len(resp.Move) != 2 {
+		t.Fatalf("respuesta = %+v", resp)
+	}
+	for _, p := range resp.Move {
+		board.ApplyMove(&b, board.Move{{Row: p[0], Col: p[1]}, board.NoPosition}, 'B')
+	}
+	if !board.CheckWin(b, 'B') || board.Serialize(b) != resp.Board {
+		t.Errorf("el movimiento %v no gana o no coincide con el tablero retornado", resp.Move)
+	}
+}
+
+// TestMoveRejectsBadRequests: método, JSON, tablero y jugador inválidos
+func TestMoveRejectsBadRequests(t *testing.T) {
+	h := NewHandler(mcts.NewMCTS(1, 10, 2, 1))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/move", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: status = %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/move", bytes.NewReader([]byte("{"))))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("JSON roto: status = %d", rec.Code)
+	}
+
+	var empty board.Board
+	for _, req := range []MoveRequest{
+		{Board: "xyz", Player: "B"},
+		{Board: board.Serialize(empty), Player: "X"},
+	} {
+		if rec := post(t, h, req); rec.Code != http.StatusBadRequest {
+			t.Errorf("%+v: status = %d", req, rec.Code)
+		}
+	}
+}
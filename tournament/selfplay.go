@@ -0,0 +1,63 @@
+package tournament
+
+import (
+	"connect6/board"
+	"connect6/mcts"
+	"encoding/json"
+	"io"
+)
+
+// Sample es una posición de entrenamiento generada por SelfPlay
+type Sample struct {
+	Board   string   `json:"board"`   // tablero antes del movimiento (board.Serialize)
+	Player  string   `json:"player"`  // quién mueve: "B" o "W"
+	Move    [][2]int `json:"move"`    // piedras jugadas como [fila, columna]
+	Outcome int      `json:"outcome"` // resultado final para quien mueve: 1 gana, -1 pierde, 0 empate
+}
+
+// SelfPlay juega 'games' partidas del motor contra sí mismo y escribe en 'w'
+// una Sample por posición, en formato JSONL (un objeto JSON por línea)
+// Parámetros:
+// - engine: Motor que juega ambos colores
+// - games: Número de partidas; la partida i siembra el motor con i+1
+// - w: Destino de los registros
+// Retorna: Error de escritura, si lo hubo
+func SelfPlay(engine *mcts.MCTS, games int, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for i := 0; i < games; i++ {
+		engine.Seed(int64(i + 1))
+		engine.ClearCache()
+
+		var samples []Sample
+		winner := playGame(engine, engine, func(before board.Board, move board.Move, player rune) {
+			samples = append(samples, newSample(before, move, player))
+		})
+
+		// El resultado solo se conoce al final: se completa antes de escribir
+		for _, s := range samples {
+			switch {
+			case winner == ' ':
+				s.Outcome = 0
+			case string(winner) == s.Player:
+				s.Outcome = 1
+			default:
+				s.Outcome = -1
+			}
+			if err := enc.Encode(s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newSample crea la Sample (aún sin resultado) de 'move' jugado en 'before'
+func newSample(before board.Board, move board.Move, player rune) Sample {
+	s := Sample{Board: board.Serialize(before), Player: string(player)}
+	for _, p := range move {
+		if p != board.NoPosition {
+			s.Move = append(s.Move, [2]int{p.Row, p.Col})
+		}
+	}
+	return s
+}
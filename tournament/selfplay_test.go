@@ -0,0 +1,61 @@
+package tournament
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"connect6/board"
+)
+
+// TestSelfPlayWritesWellFormedRecords: cada línea es una Sample JSON cuyo tablero
+// se puede leer, con el jugador al que le toca mover, un movimiento legal en ese
+// tablero y un resultado coherente con el de su rival
+func TestSelfPlayWritesWellFormedRecords(t *testing.T) {
+	var buf bytes.Buffer
+	if err := SelfPlay(fastEngine(), 1, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	outcomes := map[string]int{}
+	lines := 0
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		lines++
+		var s Sample
+		dec := json.NewDecoder(bytes.NewReader(scanner.Bytes()))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&s); err != nil {
+			t.Fatalf("línea %d: %v", lines, err)
+		}
+		b, err := board.Parse(s.Board)
+		if err != nil {
+			t.Fatalf("línea %d: tablero ilegible: %v", lines, err)
+		}
+		if want := string(board.GetCurrentPlayer(b)); s.Player != want {
+			t.Errorf("línea %d: mueve %q, want %q", lines, s.Player, want)
+		}
+		if want := board.MoveStoneCount(b); len(s.Move) != want {
+			t.Errorf("línea %d: %d piedras, want %d", lines, len(s.Move), want)
+		}
+		for _, p := range s.Move {
+			if b[p[0]][p[1]] != '\x00' {
+				t.Errorf("línea %d: %v está ocupada", lines, p)
+			}
+		}
+		if s.Outcome < -1 || s.Outcome > 1 {
+			t.Errorf("línea %d: resultado %d", lines, s.Outcome)
+		}
+		outcomes[s.Player] = s.Outcome
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if lines < 2 {
+		t.Fatalf("%d registros", lines)
+	}
+	if outcomes["B"] != -outcomes["W"] {
+		t.Errorf("resultados B %d y W %d no son opuestos", outcomes["B"], outcomes["W"])
+	}
+}
@@ -0,0 +1,109 @@
+package tournament
+
+import (
+	"connect6/board"
+	"connect6/mcts"
+)
+
+// Play enfrenta dos configuraciones de MCTS en 'games' partidas
+// Parámetros:
+// - a, b: Motores a comparar (se reutilizan entre partidas)
+// - games: Número de partidas; 'a' juega con negras (empieza) en las pares
+// Retorna: Victorias de 'a', victorias de 'b' y empates
+// Cada partida i siembra 'a' con 2*i+1 y 'b' con 2*i+2, así que con la
+// misma configuración los resultados se pueden reproducir
+func Play(a, b *mcts.MCTS, games int) (winsA, winsB, draws int) {
+	for i := 0; i < games; i++ {
+		a.Seed(int64(2*i + 1))
+		b.Seed(int64(2*i + 2))
+		a.ClearCache()
+		b.ClearCache()
+
+		black, white := a, b
+		if i%2 == 1 {
+			black, white = b, a
+		}
+
+		switch playGame(black, white, nil) {
+		case 'B':
+			if black == a {
+				winsA++
+			} else {
+				winsB++
+			}
+		case 'W':
+			if white == a {
+				winsA++
+			} else {
+				winsB++
+			}
+		default:
+			draws++
+		}
+	}
+	return winsA, winsB, draws
+}
+
+// RunMatch juega sin entrada ni salida una partida desde una posición dada,
+// pensado para baterías de posiciones tácticas que el motor debe ganar
+// Parámetros:
+// - start: Posición inicial (se copia; no se modifica)
+// - a: Motor que mueve primero, con las fichas de 'firstPlayer'
+// - b: Motor que juega con las fichas contrarias
+// - firstPlayer: A quién le toca mover en 'start' ('B' o 'W')
+// Retorna:
+// - winner: 'B' o 'W' según el ganador, ' ' si terminó en empate
+// - moves: Movimientos jugados desde 'start', en orden
+func RunMatch(start board.Board, a, b *mcts.MCTS, firstPlayer rune) (winner rune, moves []board.Move) {
+	black, white := a, b
+	if firstPlayer == 'W' {
+		black, white = b, a
+	}
+	winner = playFrom(start, firstPlayer, black, white, func(_ board.Board, move board.Move, _ rune) {
+		moves = append(moves, move)
+	})
+	return winner, moves
+}
+
+// playGame juega una partida completa desde el tablero vacío; negras mueven primero
+// 'black' y 'white' pueden ser el mismo motor (autojuego)
+// Si 'onMove' no es nil se llama con el tablero antes de cada movimiento
+// Retorna: 'B' o 'W' según el ganador, ' ' si no quedan movimientos (empate)
+func playGame(black, white *mcts.MCTS, onMove func(before board.Board, move board.Move, player rune)) rune {
+	return playFrom(board.Board{}, 'B', black, white, onMove)
+}
+
+// playFrom es playGame desde la posición 'b' con 'player' por mover
+// Si 'b' ya está terminada retorna su resultado sin jugar
+func playFrom(b board.Board, player rune, black, white *mcts.MCTS, onMove func(before board.Board, move board.Move, player rune)) rune {
+	if over, winner := board.IsTerminal(b); over {
+		return winner
+	}
+	for {
+		engine := black
+		if player == 'W' {
+			engine = white
+		}
+
+		move, ok := engine.Search(b, player)
+		if !ok {
+			return ' '
+		}
+		if onMove != nil {
+			onMove(b, move, player)
+		}
+		board.ApplyMove(&b, move, player)
+		black.Advance(move)
+		if white != black {
+			white.Advance(move)
+		}
+
+		if board.MoveWins(b, move, player) {
+			return player
+		}
+		if board.IsBoardFull(b) {
+			return ' '
+		}
+		player = board.SwitchPlayer(player)
+	}
+}
@@ -0,0 +1,76 @@
+package tournament
+
+import (
+	"testing"
+
+	"connect6/board"
+	"connect6/mcts"
+)
+
+// fastEngine es un motor con muy pocas simulaciones, para partidas rápidas
+func fastEngine() *mcts.MCTS {
+	m := mcts.NewMCTS(0, 2, 1, 60)
+	m.RolloutPolicy = mcts.RandomRollout
+	return m
+}
+
+// TestPlayIsReproducible juega unas partidas rápidas: todas cuentan en el
+// resultado y repetir el torneo con los mismos motores da lo mismo
+func TestPlayIsReproducible(t *testing.T) {
+	const games = 2
+	winsA, winsB, draws := Play(fastEngine(), fastEngine(), games)
+	if winsA+winsB+draws != games {
+		t.Fatalf("%d + %d + %d partidas, want %d", winsA, winsB, draws, games)
+	}
+	againA, againB, againDraws := Play(fastEngine(), fastEngine(), games)
+	if againA != winsA || againB != winsB || againDraws != draws {
+		t.Errorf("primer torneo %d/%d/%d, segundo %d/%d/%d", winsA, winsB, draws, againA, againB, againDraws)
+	}
+}
+
+// TestRunMatchWinsForcedPositions: desde posiciones con victoria forzada el motor
+// del atacante gana en su primer turno, o en el segundo si defiende el rival
+func TestRunMatchWinsForcedPositions(t *testing.T) {
+	// line pone 'n' piedras de 'player' en la fila 'row' desde la columna 'col'
+	line := func(b *board.Board, player rune, row, col, n int) {
+		for c := col; c < col+n; c++ {
+			b[row][c] = player
+		}
+	}
+
+	var five, four, triple board.Board
+	line(&five, 'B', 9, 5, 5) // negras completan con una piedra
+	line(&five, 'W', 3, 2, 5)
+	five[3][1], five[3][7] = 'B', 'B'
+
+	line(&four, 'W', 9, 5, 4) // blancas completan con dos piedras
+	line(&four, 'B', 14, 2, 4)
+	four[14][1], four[14][6] = 'W', 'W'
+	four[2][2] = 'B'
+
+	for _, row := range []int{2, 9, 16} { // tres cincos: las blancas solo tapan dos
+		line(&triple, 'B', row, 5, 5)
+		triple[row][4] = 'W'
+	}
+	for c := 0; c < board.BoardSize-1; c += 2 {
+		triple[0][c], triple[18][c+1] = 'W', 'W'
+	}
+
+	for _, tc := range []struct {
+		name     string
+		start    board.Board
+		first    rune
+		winner   rune
+		maxMoves int
+	}{
+		{"cinco", five, 'B', 'B', 1},
+		{"cuatro", four, 'W', 'W', 1},
+		{"tres cincos", triple, 'W', 'B', 2},
+	} {
+		winner, moves := RunMatch(tc.start, fastEngine(), fastEngine(), tc.first)
+		if winner != tc.winner || len(moves) > tc.maxMoves {
+			t.Errorf("%s: gana %q en %d movimientos %v; want %q en %d como mucho",
+				tc.name, winner, len(moves), moves, tc.winner, tc.maxMoves)
+		}
+	}
+}
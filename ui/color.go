@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+)
+
+// Códigos ANSI usados al imprimir el tablero en color
+const (
+	colorReset = "\033[0m"
+	colorBlack = "\033[1;34m" // fichas negras: azul
+	colorWhite = "\033[1;33m" // fichas blancas: amarillo
+	colorLast  = "\033[1;32m" // última jugada: verde
+	colorWin   = "\033[1;7m"  // línea ganadora: vídeo inverso
+
+	clearCode = "\033[H\033[2J" // cursor al inicio y borrado de pantalla
+)
+
+// colorEnabled indica si PrintBoard usa colores ANSI
+var colorEnabled bool
+
+// SetColor activa o desactiva los colores ANSI del tablero
+// Si la salida estándar no es una terminal (p.ej. redirigida a un archivo)
+// el color queda desactivado para no ensuciar la salida
+func SetColor(on bool) {
+	colorEnabled = on && stdoutIsTerminal()
+}
+
+// clearEnabled indica si ClearScreen borra la pantalla
+var clearEnabled bool
+
+// SetClear activa o desactiva el borrado de pantalla entre turnos
+// Igual que SetColor, queda desactivado si la salida estándar no es una terminal
+func SetClear(on bool) {
+	clearEnabled = on && stdoutIsTerminal()
+}
+
+// ClearScreen borra la pantalla con códigos ANSI si SetClear lo activó
+func ClearScreen() {
+	if clearEnabled {
+		fmt.Print(clearCode)
+	}
+}
+
+// stdoutIsTerminal indica si la salida estándar es una terminal; es una
+// variable para que las pruebas puedan simular una
+var stdoutIsTerminal = func() bool {
+	return isTerminal(os.Stdout)
+}
+
+// isTerminal indica si el archivo es una terminal (dispositivo de caracteres)
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// cellColor retorna el código de color de una celda, o "" si no lleva color
+func cellColor(cell rune, mark cellMark) string {
+	if !colorEnabled {
+		return ""
+	}
+	switch {
+	case mark == markWin:
+		return colorWin
+	case mark == markLast:
+		return colorLast
+	case cell == 'B':
+		return colorBlack
+	case cell == 'W':
+		return colorWhite
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"os"
+	"testing"
+)
+
+// TestClearScreenOnlyOnTerminal: el código de borrado solo se emite si -clear
+// está activo y la salida es una terminal
+func TestClearScreenOnlyOnTerminal(t *testing.T) {
+	defer func(tty func() bool, on bool) { stdoutIsTerminal, clearEnabled = tty, on }(stdoutIsTerminal, clearEnabled)
+
+	for _, tc := range []struct {
+		on, tty bool
+		want    string
+	}{
+		{true, true, clearCode},
+		{true, false, ""},
+		{false, true, ""},
+		{false, false, ""},
+	} {
+		stdoutIsTerminal = func() bool { return tc.tty }
+		SetClear(tc.on)
+		if got := captureStdout(t, ClearScreen); got != tc.want {
+			t.Errorf("clear=%v terminal=%v: salida %q, want %q", tc.on, tc.tty, got, tc.want)
+		}
+	}
+}
+
+// TestPipeIsNotTerminal: una tubería (salida redirigida) no cuenta como terminal
+func TestPipeIsNotTerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	if isTerminal(w) {
+		t.Error("isTerminal(tubería) = true")
+	}
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"connect6/board"
+	"connect6/mcts"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Parámetros de la búsqueda corta del comando 'hint'
+const (
+	hintTimeLimit  = 1 // segundos
+	hintIterations = 100000
+	hintMaxDepth   = 10
+)
+
+// runCommand atiende los comandos especiales del prompt de jugada
+// Comandos:
+//   - save archivo: guarda el tablero serializado en 'archivo'
+//   - load archivo: reemplaza el tablero por el guardado en 'archivo'
+//   - hint: sugiere un movimiento para 'player' con una búsqueda corta
+//   - eval: muestra la evaluación de la posición para ambos jugadores
+//
+// 'hint' y 'eval' usan las reglas de la consola (ver SetRules)
+//
+// Retorna:
+//   - handled: true si 'fields' era un comando (válido o no)
+//   - loaded: true si se cargó una posición nueva en 'b'
+func (c *Console) runCommand(b *board.Board, player rune, fields []string) (handled, loaded bool) {
+	switch strings.ToLower(fields[0]) {
+	case "hint":
+		fmt.Fprintln(c.out, T("hint.thinking"))
+		engine := mcts.NewMCTS(time.Now().UnixNano(), hintIterations, hintMaxDepth, hintTimeLimit)
+		engine.Rules = c.rules
+		move, ok := engine.Search(*b, player)
+		if !ok {
+			fmt.Fprintln(c.out, T("no.moves"))
+			return true, false
+		}
+		fmt.Fprintln(c.out, Tf("hint.move", PieceName(player), formatMove(move)))
+		return true, false
+
+	case "eval":
+		// Solo informativo: no cambia el tablero ni el turno
+		fmt.Fprintln(c.out, Tf("eval.score", T("black"), c.rules.EvaluateBoard(*b, 'B'), T("white"), c.rules.EvaluateBoard(*b, 'W')))
+		return true, false
+
+	case "save":
+		if len(fields) != 2 {
+			fmt.Fprintln(c.out, T("save.usage"))
+			return true, false
+		}
+		if err := saveBoard(fields[1], *b); err != nil {
+			fmt.Fprintln(c.out, Tf("save.error", err))
+			return true, false
+		}
+		fmt.Fprintln(c.out, Tf("save.done", fields[1]))
+		return true, false
+
+	case "load":
+		if len(fields) != 2 {
+			fmt.Fprintln(c.out, T("load.usage"))
+			return true, false
+		}
+		loadedBoard, err := loadBoard(fields[1])
+		if err != nil {
+			fmt.Fprintln(c.out, Tf("load.error", err))
+			return true, false
+		}
+		*b = loadedBoard
+		fmt.Fprintln(c.out, Tf("load.done", fields[1]))
+		return true, true
+	}
+	return false, false
+}
+
+// saveBoard escribe el tablero serializado (board.Serialize) en 'path'
+func saveBoard(path string, b board.Board) error {
+	return os.WriteFile(path, []byte(board.Serialize(b)+"\n"), 0644)
+}
+
+// loadBoard lee un tablero guardado con saveBoard y rechaza los conteos de
+// piedras imposibles (board.ValidatePosition)
+func loadBoard(path string) (board.Board, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return board.Board{}, err
+	}
+	return board.ParsePosition(strings.TrimSpace(string(data)))
+}
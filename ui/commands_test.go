@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"bytes"
+	"connect6/board"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestSaveLoadRoundTrip guarda el tablero con 'save' y lo recupera con 'load'
+// desde el prompt de jugada; 'save' no consume el turno
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "partida.txt")
+	var saved board.Board
+	saved[9][9] = 'B'
+	saved[9][10] = 'W'
+	saved[10][10] = 'W'
+
+	b := saved
+	c := NewConsole(strings.NewReader("save "+path+"\n0 0 0 1\n"), io.Discard)
+	move, ok := c.GetPlayerMove(&b, 'B')
+	if !ok || move != (board.Move{{Row: 0, Col: 0}, {Row: 0, Col: 1}}) {
+		t.Fatalf("tras 'save': move = %v, ok = %v", move, ok)
+	}
+
+	var other board.Board
+	c = NewConsole(strings.NewReader("load "+path+"\n"), io.Discard)
+	if _, ok := c.GetPlayerMove(&other, 'B'); ok {
+		t.Fatal("'load' no avisó de la posición nueva")
+	}
+	if other != saved {
+		t.Errorf("el tablero cargado no es el guardado:\n%s\n%s", board.Serialize(other), board.Serialize(saved))
+	}
+}
+
+// TestGetPlayerMoveInputClosed: al agotarse la entrada se retorna InputClosed
+// en lugar de volver a pedir la jugada
+func TestGetPlayerMoveInputClosed(t *testing.T) {
+	var b board.Board
+	for _, input := range []string{"", "\n", "1 2 3\n"} {
+		c := NewConsole(strings.NewReader(input), io.Discard)
+		if move, ok := c.GetPlayerMove(&b, 'B'); !ok || move != InputClosed {
+			t.Errorf("entrada %q: move = %v, ok = %v", input, move, ok)
+		}
+	}
+}
+
+// TestEvalCommandPrintsScores: 'eval' muestra la evaluación numérica de ambos
+// jugadores, no toca el tablero y vuelve a pedir la jugada
+func TestEvalCommandPrintsScores(t *testing.T) {
+	defer SetLang(Lang)
+	SetLang("es")
+	var b board.Board
+	b[9][9] = 'B'
+	b[9][10] = 'W'
+	b[10][10] = 'W'
+	before := b
+
+	var out bytes.Buffer
+	c := NewConsole(strings.NewReader("eval\n0 0 0 1\n"), &out)
+	move, ok := c.GetPlayerMove(&b, 'B')
+	if !ok || move != (board.Move{{Row: 0, Col: 0}, {Row: 0, Col: 1}}) {
+		t.Fatalf("move = %v, ok = %v", move, ok)
+	}
+	if b != before {
+		t.Error("'eval' modificó el tablero")
+	}
+	want := Tf("eval.score", T("black"), board.EvaluateBoard(b, 'B'), T("white"), board.EvaluateBoard(b, 'W'))
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("falta %q en:\n%s", want, out.String())
+	}
+	if prompts := strings.Count(out.String(), Tf("prompt.two", PieceName('B'))); prompts != 2 {
+		t.Errorf("se pidió la jugada %d veces, want 2", prompts)
+	}
+}
+
+// TestEvalCommandUsesConsoleRules: 'eval' evalúa con las reglas de SetRules
+// (aquí otros pesos), no con las por defecto
+func TestEvalCommandUsesConsoleRules(t *testing.T) {
+	defer SetLang(Lang)
+	SetLang("es")
+	var b board.Board
+	b[9][9] = 'B'
+	b[9][10] = 'B'
+	b[9][11] = 'B'
+	b[5][5] = 'W'
+
+	w := board.DefaultWeights
+	w.ThreeOpen *= 10
+	w.Single *= 10
+	rules := board.DefaultRules.WithWeights(w)
+
+	var out bytes.Buffer
+	c := NewConsole(strings.NewReader("eval\n0 0 0 1\n"), &out)
+	c.SetRules(rules)
+	if _, ok := c.GetPlayerMove(&b, 'B'); !ok {
+		t.Fatal("GetPlayerMove no retornó la jugada")
+	}
+	want := Tf("eval.score", T("black"), rules.EvaluateBoard(b, 'B'), T("white"), rules.EvaluateBoard(b, 'W'))
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("falta %q en:\n%s", want, out.String())
+	}
+	stale := Tf("eval.score", T("black"), board.EvaluateBoard(b, 'B'), T("white"), board.EvaluateBoard(b, 'W'))
+	if stale == want {
+		t.Fatal("los pesos de la prueba no cambian la evaluación")
+	}
+	if strings.Contains(out.String(), stale) {
+		t.Errorf("'eval' usó las reglas por defecto:\n%s", out.String())
+	}
+}
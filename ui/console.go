@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"bufio"
+	"connect6/board"
+	"io"
+	"os"
+)
+
+// Console agrupa la entrada y la salida de los prompts interactivos
+// (GetPlayerMove, ShowGameMenu, AskSwap y sus comandos), de modo que se
+// puedan alimentar con una entrada guionizada y capturar su salida
+type Console struct {
+	in    *bufio.Reader
+	out   io.Writer
+	rules board.Rules // reglas con las que evalúan 'eval' y 'hint' (ver SetRules)
+}
+
+// NewConsole crea una consola que lee de 'r' y escribe en 'w' con las reglas
+// por defecto (board.DefaultRules)
+func NewConsole(r io.Reader, w io.Writer) *Console {
+	return &Console{in: bufio.NewReader(r), out: w, rules: board.DefaultRules}
+}
+
+// SetRules hace que los comandos 'eval' y 'hint' usen las reglas de la partida
+// en curso (pesos, WinLength, topes de generación) en lugar de las por defecto
+func (c *Console) SetRules(rules board.Rules) {
+	c.rules = rules
+}
+
+// stdConsole es la consola de la partida real: entrada y salida estándar
+var stdConsole = NewConsole(os.Stdin, os.Stdout)
+
+// StdConsole retorna la consola de la entrada y la salida estándar
+func StdConsole() *Console {
+	return stdConsole
+}
+
+// GetPlayerMove pide el movimiento por la entrada estándar (ver Console.GetPlayerMove)
+func GetPlayerMove(b *board.Board, player rune) (board.Move, bool) {
+	return stdConsole.GetPlayerMove(b, player)
+}
+
+// ShowGameMenu muestra el menú de inicio por la salida estándar (ver Console.ShowGameMenu)
+func ShowGameMenu() rune {
+	return stdConsole.ShowGameMenu()
+}
+
+// AskSwap pregunta por la entrada estándar (ver Console.AskSwap)
+func AskSwap(player rune) bool {
+	return stdConsole.AskSwap(player)
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"bytes"
+	"connect6/board"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestShowGameMenu comprueba que "sí" sea jugar primero (negras) y que
+// cualquier otra respuesta, o la entrada cerrada, deje abrir al bot
+func TestShowGameMenu(t *testing.T) {
+	cases := map[string]rune{
+		"s\n": 'B',
+		"Y\n": 'B',
+		"n\n": 'W',
+		"\n":  'W',
+		"":    'W',
+	}
+	for input, want := range cases {
+		c := NewConsole(strings.NewReader(input), io.Discard)
+		if got := c.ShowGameMenu(); got != want {
+			t.Errorf("ShowGameMenu(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+// TestConsoleParsesInjectedMoves: la consola lee jugadas de un io.Reader
+// cualquiera (con espacios de sobra o mayúsculas en los comandos) y escribe
+// sus prompts en el io.Writer dado, no en la salida estándar
+func TestConsoleParsesInjectedMoves(t *testing.T) {
+	var b board.Board
+	var out bytes.Buffer
+	c := NewConsole(strings.NewReader("  9   9  \n"), &out)
+	if move, ok := c.GetPlayerMove(&b, 'B'); !ok || move != (board.Move{{Row: 9, Col: 9}, board.NoPosition}) {
+		t.Fatalf("apertura: move = %v, ok = %v", move, ok)
+	}
+	if !strings.Contains(out.String(), Tf("prompt.one", PieceName('B'))) {
+		t.Errorf("el prompt no llegó al writer: %q", out.String())
+	}
+
+	b[9][9] = 'B'
+	c = NewConsole(strings.NewReader("\n8 8 10 10\nRESIGN\n"), io.Discard)
+	if move, ok := c.GetPlayerMove(&b, 'W'); !ok || move != (board.Move{{Row: 8, Col: 8}, {Row: 10, Col: 10}}) {
+		t.Fatalf("dos piedras: move = %v, ok = %v", move, ok)
+	}
+	if move, ok := c.GetPlayerMove(&b, 'W'); !ok || move != board.ResignMove {
+		t.Errorf("resign: move = %v, ok = %v", move, ok)
+	}
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"fmt"
+)
+
+// Lang es el idioma de los mensajes al usuario: "es" (por defecto) o "en"
+// Se cambia con SetLang
+var Lang = "es"
+
+// messages contiene los textos de cada idioma por clave; los que llevan
+// verbos de formato se usan con Tf
+var messages = map[string]map[string]string{
+	"es": {
+		"black":          "Negras",
+		"white":          "Blancas",
+		"prompt.one":     "%s, ingresa una posición (fila columna): ",
+		"prompt.two":     "%s, ingresa dos posiciones (fila1 columna1 fila2 columna2): ",
+		"err.read":       "Error: no se pudo leer la entrada.",
+		"err.numbers":    "Error: Entrada inválida. Usa %d números separados por espacios.",
+		"err.move":       "Movimiento inválido: %s. Intenta nuevamente.",
+		"why.same":       "las dos posiciones son la misma",
+		"why.range":      "%s está fuera del tablero (filas y columnas van de %d a %d)",
+		"why.occupied":   "la casilla %s ya está ocupada",
+		"why.other":      "movimiento no permitido",
+		"why.opening":    "la apertura es de una sola piedra",
+		"why.two":        "el movimiento debe colocar dos piedras",
+		"menu.first":     "¿Quieres jugar primero (con negras)? (s/n): ",
+		"swap.ask":       "%s, ¿quieres intercambiar colores y quedarte con la piedra de apertura? (s/n): ",
+		"swap.no":        "No hay intercambio de colores.",
+		"swap.yes":       "Se intercambian los colores: ahora juegas con %s.",
+		"turn":           "Turno %d | Piedras en el tablero: %d",
+		"game.length":    "Partida terminada tras %d turnos | Piedras en el tablero: %d",
+		"move.played":    "%s juega %s",
+		"search.stats":   "Simulaciones: %d | Visitas: %d | Victoria estimada: %.1f%%",
+		"search.pv":      "Variante principal:",
+		"result.black":   "¡Las fichas Negras ganan!",
+		"result.white":   "¡Las fichas Blancas ganan!",
+		"result.draw":    "¡Es un empate!",
+		"win.line":       "Línea ganadora:",
+		"hint.thinking":  "Pensando una sugerencia...",
+		"hint.move":      "Sugerencia para %s: %s",
+		"no.moves":       "No hay movimientos legales.",
+		"eval.score":     "Evaluación: %s %.0f | %s %.0f",
+		"analyze.header": "Mejores movimientos para %s:",
+		"analyze.line":   "%2d. %s | Visitas: %d | Victoria: %.1f%% | Evaluación: %.0f",
+		"save.usage":     "Uso: save archivo",
+		"save.error":     "Error al guardar: %v",
+		"save.done":      "Partida guardada en %s",
+		"load.usage":     "Uso: load archivo",
+		"load.error":     "Error al cargar: %v",
+		"load.done":      "Partida cargada desde %s",
+		"bot.turn":       "Turno del Bot (%s)...",
+		"bot.forced":     "Victoria forzada encontrada.",
+		"bot.error":      "Error en la jugada del bot: %v",
+		"bot.nomoves":    "No quedan movimientos legales.",
+		"human.turn":     "Tu turno (%s)",
+		"block.warning":  "Aviso: debes bloquear en",
+		"error":          "Error: %v",
+		"resigned":       "%s se rinden.",
+		"abandoned":      "Partida abandonada: la entrada se cerró.",
+		"turn.cap":       "Se alcanzó el límite de %d turnos.",
+
+		// Mensajes de main (banderas y modos sin partida)
+		"main.option":        "Opción %s: %v",
+		"main.config":        "Error en el archivo de ajustes: %v",
+		"main.config.diff":   "difficulty: dificultad desconocida %q",
+		"main.config.lang":   "lang: idioma desconocido %q",
+		"main.lang":          "Error: idioma desconocido: %s",
+		"main.base":          "Error: -base debe ser 0 o 1",
+		"main.serving":       "Sirviendo la API en %s",
+		"main.mode":          "Error: modo desconocido: %s",
+		"main.engine":        "Error: motor desconocido: %s",
+		"main.difficulty":    "Error: dificultad desconocida: %s",
+		"main.position":      "Error: posición inválida: %v",
+		"main.moves.combine": "Error: -moves y -position no se pueden combinar",
+		"main.moves.error":   "Error en el archivo de jugadas: %v",
+		"main.book.error":    "Error al cargar el libro: %v",
+		"main.selfplay.done": "Datos de autojuego guardados en %s",
+	},
+	"en": {
+		"black":          "Black",
+		"white":          "White",
+		"prompt.one":     "%s, enter a position (row column): ",
+		"prompt.two":     "%s, enter two positions (row1 column1 row2 column2): ",
+		"err.read":       "Error: could not read input.",
+		"err.numbers":    "Error: invalid input. Enter %d numbers separated by spaces.",
+		"err.move":       "Invalid move: %s. Try again.",
+		"why.same":       "both positions are the same",
+		"why.range":      "%s is off the board (rows and columns go from %d to %d)",
+		"why.occupied":   "cell %s is already taken",
+		"why.other":      "move not allowed",
+		"why.opening":    "the opening is a single stone",
+		"why.two":        "the move must place two stones",
+		"menu.first":     "Do you want to play first (as Black)? (y/n): ",
+		"swap.ask":       "%s, do you want to swap colors and take the opening stone? (y/n): ",
+		"swap.no":        "No color swap.",
+		"swap.yes":       "Colors swapped: you now play %s.",
+		"turn":           "Turn %d | Stones on the board: %d",
+		"game.length":    "Game over after %d turns | Stones on the board: %d",
+		"move.played":    "%s plays %s",
+		"search.stats":   "Simulations: %d | Visits: %d | Estimated win rate: %.1f%%",
+		"search.pv":      "Principal variation:",
+		"result.black":   "Black wins!",
+		"result.white":   "White wins!",
+		"result.draw":    "It's a draw!",
+		"win.line":       "Winning line:",
+		"hint.thinking":  "Thinking of a hint...",
+		"hint.move":      "Hint for %s: %s",
+		"no.moves":       "No legal moves.",
+		"eval.score":     "Evaluation: %s %.0f | %s %.0f",
+		"analyze.header": "Best moves for %s:",
+		"analyze.line":   "%2d. %s | Visits: %d | Win: %.1f%% | Evaluation: %.0f",
+		"save.usage":     "Usage: save file",
+		"save.error":     "Error saving: %v",
+		"save.done":      "Game saved to %s",
+		"load.usage":     "Usage: load file",
+		"load.error":     "Error loading: %v",
+		"load.done":      "Game loaded from %s",
+		"bot.turn":       "Bot's turn (%s)...",
+		"bot.forced":     "Forced win found.",
+		"bot.error":      "Error in the bot's move: %v",
+		"bot.nomoves":    "No legal moves left.",
+		"human.turn":     "Your turn (%s)",
+		"block.warning":  "Warning: you must block at",
+		"error":          "Error: %v",
+		"resigned":       "%s resigns.",
+		"abandoned":      "Game abandoned: input was closed.",
+		"turn.cap":       "The %d-turn limit was reached.",
+
+		// Mensajes de main (banderas y modos sin partida)
+		"main.option":        "Option %s: %v",
+		"main.config":        "Error in the settings file: %v",
+		"main.config.diff":   "difficulty: unknown difficulty %q",
+		"main.config.lang":   "lang: unknown language %q",
+		"main.lang":          "Error: unknown language: %s",
+		"main.base":          "Error: -base must be 0 or 1",
+		"main.serving":       "Serving the API on %s",
+		"main.mode":          "Error: unknown mode: %s",
+		"main.engine":        "Error: unknown engine: %s",
+		"main.difficulty":    "Error: unknown difficulty: %s",
+		"main.position":      "Error: invalid position: %v",
+		"main.moves.combine": "Error: -moves and -position cannot be combined",
+		"main.moves.error":   "Error in the moves file: %v",
+		"main.book.error":    "Error loading the book: %v",
+		"main.selfplay.done": "Self-play data saved to %s",
+	},
+}
+
+// HasLang indica si hay mensajes en el idioma 'lang'
+func HasLang(lang string) bool {
+	_, ok := messages[lang]
+	return ok
+}
+
+// SetLang cambia el idioma de los mensajes
+// Retorna: false (sin cambiar nada) si 'lang' no es "es" ni "en"
+func SetLang(lang string) bool {
+	if !HasLang(lang) {
+		return false
+	}
+	Lang = lang
+	return true
+}
+
+// T retorna el texto de 'key' en el idioma actual (en español si falta)
+func T(key string) string {
+	if msg, ok := messages[Lang][key]; ok {
+		return msg
+	}
+	return messages["es"][key]
+}
+
+// Tf da formato al texto de 'key' con 'args', como fmt.Sprintf
+func Tf(key string, args ...interface{}) string {
+	return fmt.Sprintf(T(key), args...)
+}
+
+// isYes indica si la respuesta es afirmativa en cualquiera de los idiomas (s/S/y/Y)
+func isYes(answer string) bool {
+	switch answer {
+	case "s", "S", "y", "Y":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"connect6/board"
+)
+
+// TestMessagesInEveryLanguage comprueba que cada clave tenga texto en todos los
+// idiomas con los mismos verbos de formato
+func TestMessagesInEveryLanguage(t *testing.T) {
+	for lang, msgs := range messages {
+		for other, others := range messages {
+			for key, msg := range msgs {
+				translated, ok := others[key]
+				if !ok {
+					t.Errorf("la clave %q de %q falta en %q", key, lang, other)
+					continue
+				}
+				if verbs(msg) != verbs(translated) {
+					t.Errorf("%q: verbos %q en %q y %q en %q", key, verbs(msg), lang, verbs(translated), other)
+				}
+			}
+		}
+	}
+}
+
+// TestTfUsesCurrentLanguage comprueba que los mensajes de main sigan a SetLang
+func TestTfUsesCurrentLanguage(t *testing.T) {
+	defer SetLang(Lang)
+	if !SetLang("en") {
+		t.Fatal("SetLang(en) falló")
+	}
+	if got := Tf("main.mode", "xyz"); got != "Error: unknown mode: xyz" {
+		t.Errorf("en: %q", got)
+	}
+	SetLang("es")
+	if got := Tf("main.mode", "xyz"); got != "Error: modo desconocido: xyz" {
+		t.Errorf("es: %q", got)
+	}
+	if SetLang("fr") || Lang != "es" {
+		t.Errorf("SetLang(fr) cambió el idioma a %q", Lang)
+	}
+}
+
+// TestShowResultFollowsLanguage: el mismo resultado se anuncia en el idioma elegido
+func TestShowResultFollowsLanguage(t *testing.T) {
+	defer SetLang(Lang)
+	for _, tc := range []struct {
+		lang   string
+		winner rune
+		want   string
+	}{
+		{"es", 'B', "¡Las fichas Negras ganan!\n"},
+		{"en", 'B', "Black wins!\n"},
+		{"es", 'W', "¡Las fichas Blancas ganan!\n"},
+		{"en", 'W', "White wins!\n"},
+		{"es", ' ', "¡Es un empate!\n"},
+		{"en", ' ', "It's a draw!\n"},
+	} {
+		SetLang(tc.lang)
+		if got := captureStdout(t, func() { ShowResult(tc.winner) }); got != tc.want {
+			t.Errorf("%s, %q: ShowResult imprimió %q, want %q", tc.lang, tc.winner, got, tc.want)
+		}
+	}
+}
+
+// TestMoveErrorTextTranslates: los errores de board.ApplyMoveChecked salen en el
+// idioma actual y con las coordenadas de CoordinateBase
+func TestMoveErrorTextTranslates(t *testing.T) {
+	defer SetLang(Lang)
+	var b board.Board
+	b[9][9] = 'B'
+	err := board.ApplyMoveChecked(&b, board.Move{{Row: 9, Col: 9}, {Row: 3, Col: 3}}, 'W')
+	if !errors.Is(err, board.ErrOccupied) {
+		t.Fatalf("ApplyMoveChecked = %v, want ErrOccupied", err)
+	}
+
+	SetLang("en")
+	if got, want := MoveErrorText(err), "cell "+formatPos(board.Position{Row: 9, Col: 9})+" is already taken"; got != want {
+		t.Errorf("en: %q, want %q", got, want)
+	}
+	SetLang("es")
+	if got, want := MoveErrorText(err), "la casilla "+formatPos(board.Position{Row: 9, Col: 9})+" ya está ocupada"; got != want {
+		t.Errorf("es: %q, want %q", got, want)
+	}
+	if got := MoveErrorText(errors.New("otro")); got != "otro" {
+		t.Errorf("un error que no es de movimiento cambió a %q", got)
+	}
+}
+
+// verbs retorna los verbos de formato de 'msg' en orden (p.ej. "%s%d")
+func verbs(msg string) string {
+	var sb strings.Builder
+	for i := 0; i < len(msg)-1; i++ {
+		if msg[i] != '%' {
+			continue
+		}
+		j := i + 1
+		for j < len(msg) && strings.IndexByte("0123456789.-+ ", msg[j]) >= 0 {
+			j++
+		}
+		if j < len(msg) {
+			sb.WriteString(msg[i : j+1])
+		}
+		i = j
+	}
+	return sb.String()
+}
@@ -1,86 +1,330 @@
 package ui
 
 import (
-	"fmt"
 	"connect6/board"
+	"connect6/mcts"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
 )
 
+// InputClosed es el movimiento que retorna GetPlayerMove cuando la entrada
+// estándar se cerró (io.EOF): la partida debe terminar sin resultado
+var InputClosed = board.Move{{Row: -2, Col: -2}, {Row: -2, Col: -2}}
+
+// CoordinateBase es el número con el que empiezan filas y columnas en pantalla:
+// 0 (por defecto, como el tablero interno) o 1. Afecta por igual a los
+// encabezados del tablero, a la entrada de GetPlayerMove y a los mensajes
+var CoordinateBase = 0
+
+// formatPos da formato "(fila,col)" a una posición en la base de CoordinateBase
+func formatPos(p board.Position) string {
+	return fmt.Sprintf("(%d,%d)", p.Row+CoordinateBase, p.Col+CoordinateBase)
+}
+
 // PrintBoard muestra el tablero con formato legible en consola
 // Parámetros:
 // - b: Tablero a mostrar
 // Formato:
-//   - Encabezado con números de columna (0-18)
-//   - Filas numeradas (0-18) a la izquierda
+//   - Encabezado con números de columna (0-18, o 1-19 si CoordinateBase es 1)
+//   - Filas numeradas igual a la izquierda
 //   - 'B' para fichas negras, 'W' para blancas, '.' para celdas vacías
 func PrintBoard(b board.Board) {
-    fmt.Print("    ") // Ajustar espacio para el encabezado de columnas
-    for c := 0; c < board.BoardSize; c++ {
-        fmt.Printf("%2d ", c) // Encabezado de columnas (0-18)
-    }
-    fmt.Println()
-
-    for r := 0; r < board.BoardSize; r++ {
-        fmt.Printf("%2d ", r) // Encabezado de filas (0-18)
-        for c := 0; c < board.BoardSize; c++ {
-            char := b[r][c]
-            if char == 0 { // 0 representa celda vacía
-                char = '.'
-            }
-            fmt.Printf(" %c ", char)
-        }
-        fmt.Println()
-    }
-    fmt.Println()
+	printBoard(b, nil)
+}
+
+// PrintBoardHighlighted muestra el tablero resaltando la última jugada y la línea ganadora
+// Parámetros:
+// - b: Tablero a mostrar
+// - last: Última jugada (una o dos piedras); Move{} si aún no hay jugadas
+// - winLine: Posiciones de la línea ganadora, o nil si no hay ganador
+// Sin color, la última jugada se marca entre corchetes [B] y la línea ganadora
+// entre asteriscos *B* (la línea tiene prioridad si coinciden)
+func PrintBoardHighlighted(b board.Board, last board.Move, winLine []board.Position) {
+	marks := make(map[board.Position]cellMark)
+	if last != (board.Move{}) {
+		marks[last[0]] = markLast
+		if last[1] != board.NoPosition {
+			marks[last[1]] = markLast
+		}
+	}
+	for _, p := range winLine {
+		marks[p] = markWin
+	}
+	printBoard(b, marks)
+}
+
+// cellMark indica cómo se resalta una celda al imprimir
+type cellMark int
+
+const (
+	markNone cellMark = iota
+	markLast          // piedra de la última jugada
+	markWin           // piedra de la línea ganadora
+)
+
+// printBoard imprime el tablero resaltando las posiciones de 'marks'
+func printBoard(b board.Board, marks map[board.Position]cellMark) {
+	fmt.Print("    ") // Ajustar espacio para el encabezado de columnas
+	for c := 0; c < board.BoardSize; c++ {
+		fmt.Printf("%2d ", c+CoordinateBase) // Encabezado de columnas
+	}
+	fmt.Println()
+
+	for r := 0; r < board.BoardSize; r++ {
+		fmt.Printf("%2d ", r+CoordinateBase) // Encabezado de filas
+		for c := 0; c < board.BoardSize; c++ {
+			char := b[r][c]
+			if char == 0 { // 0 representa celda vacía
+				char = '.'
+			}
+			mark := marks[board.Position{Row: r, Col: c}]
+			switch color := cellColor(char, mark); {
+			case color != "":
+				fmt.Printf(" %s%c%s ", color, char, colorReset)
+			case mark == markWin:
+				fmt.Printf("*%c*", char)
+			case mark == markLast:
+				fmt.Printf("[%c]", char)
+			default:
+				fmt.Printf(" %c ", char)
+			}
+		}
+		fmt.Println()
+	}
+	fmt.Println()
 }
 
 // GetPlayerMove obtiene y valida el movimiento del jugador humano
 // Flujo:
-//   1. Solicita entrada con 4 números (fila1 col1 fila2 col2)
-//   2. Valida formato numérico
-//   3. Valida posiciones con board.IsValidMove
-//   4. Repite hasta obtener entrada válida
+//  1. Solicita entrada con 4 números (fila1 col1 fila2 col2),
+//     o 2 números (fila columna) en la apertura de una sola piedra
+//  2. Atiende comandos ('save archivo', 'load archivo', 'hint', 'eval') sin consumir el turno;
+//     'resign' devuelve board.ResignMove
+//  3. Valida formato numérico
+//  4. Valida posiciones con board.IsValidMove
+//  5. Repite hasta obtener entrada válida
+//
+// Parámetros:
+//   - b: Tablero actual (lo reemplaza el comando 'load')
+//   - player: Fichas del jugador que mueve ('B' o 'W')
+//
 // Retorna:
-//   - Move válido listo para aplicar al tablero
-func GetPlayerMove(b board.Board) board.Move {
-	var row1, col1, row2, col2 int
+//   - Move válido listo para aplicar al tablero, board.ResignMove si el jugador se rinde
+//     o InputClosed si la entrada se cerró
+//   - false si no hubo movimiento porque se cargó otra posición en 'b'
+func (c *Console) GetPlayerMove(b *board.Board, player rune) (board.Move, bool) {
 	for {
-		fmt.Print("Ingresa dos posiciones (fila1 columna1 fila2 columna2): ")
-		_, err := fmt.Scan(&row1, &col1, &row2, &col2)
-		
-		if err != nil {
-			fmt.Println("Error: Entrada inválida. Usa 4 números separados por espacios.")
-			// Limpiar buffer de entrada
-			var discard string
-			fmt.Scanln(&discard)
+		stones := board.MoveStoneCount(*b)
+		if stones == 1 {
+			fmt.Fprint(c.out, Tf("prompt.one", PieceName(player)))
+		} else {
+			fmt.Fprint(c.out, Tf("prompt.two", PieceName(player)))
+		}
+
+		line, err := c.in.ReadString('\n')
+		fields := strings.Fields(line)
+		if err != nil && len(fields) == 0 {
+			if errors.Is(err, io.EOF) {
+				// Entrada agotada (p.ej. un script que terminó): no hay más jugadas
+				fmt.Fprintln(c.out)
+				return InputClosed, true
+			}
+			fmt.Fprintln(c.out, T("err.read"))
+			continue
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) == 1 && strings.EqualFold(fields[0], "resign") {
+			return board.ResignMove, true
+		}
+
+		if handled, loaded := c.runCommand(b, player, fields); handled {
+			if loaded {
+				return board.Move{}, false
+			}
+			continue
+		}
+
+		coords, ok := parseCoords(fields, stones*2)
+		if !ok {
+			fmt.Fprintln(c.out, Tf("err.numbers", stones*2))
 			continue
 		}
-		
-		p1 := board.Position{Row: row1, Col: col1}
-		p2 := board.Position{Row: row2, Col: col2}
-		
-		if board.IsValidMove(b, p1, p2) {
-			return board.Move{p1, p2}
+		for i := range coords {
+			coords[i] -= CoordinateBase
+		}
+
+		move := board.Move{{Row: coords[0], Col: coords[1]}, board.NoPosition}
+		if stones == 2 {
+			move[1] = board.Position{Row: coords[2], Col: coords[3]}
+		}
+		if board.IsValidMove(*b, move[0], move[1]) {
+			return move, true
+		}
+
+		fmt.Fprintln(c.out, Tf("err.move", moveError(*b, move)))
+	}
+}
+
+// moveError explica por qué 'move' no es legal en 'b'
+// Retorna: Motivo específico (fuera de rango, casilla ocupada o posiciones repetidas)
+// Con CoordinateBase 1, "0 0" es la posición (-1,-1), igual a board.NoPosition:
+// solo la segunda piedra puede faltar
+func moveError(b board.Board, move board.Move) string {
+	if move[1] != board.NoPosition && move[0] == move[1] {
+		return T("why.same")
+	}
+	for i, p := range move {
+		if i == 1 && p == board.NoPosition {
+			continue
+		}
+		if p.Row < 0 || p.Row >= board.BoardSize || p.Col < 0 || p.Col >= board.BoardSize {
+			return Tf("why.range", formatPos(p), CoordinateBase, board.BoardSize-1+CoordinateBase)
+		}
+		if b[p.Row][p.Col] != '\x00' {
+			return Tf("why.occupied", formatPos(p))
 		}
-		
-		fmt.Println("Movimiento inválido. Intenta nuevamente.")
 	}
+	return T("why.other")
 }
 
-// ShowGameMenu muestra el menú de inicio del juego
+// MoveErrorText traduce el error de board.ApplyMoveChecked (board.MoveError)
+// al idioma actual, con las coordenadas en CoordinateBase
+// Retorna: El texto del error; los que no son de movimiento, sin cambios
+func MoveErrorText(err error) string {
+	var moveErr *board.MoveError
+	if !errors.As(err, &moveErr) {
+		return err.Error()
+	}
+	switch moveErr.Err {
+	case board.ErrOpeningStones:
+		return T("why.opening")
+	case board.ErrTwoStones:
+		return T("why.two")
+	case board.ErrSamePosition:
+		return T("why.same")
+	case board.ErrOutOfBounds:
+		return Tf("why.range", formatPos(moveErr.Pos), CoordinateBase, board.BoardSize-1+CoordinateBase)
+	case board.ErrOccupied:
+		return Tf("why.occupied", formatPos(moveErr.Pos))
+	}
+	return T("why.other")
+}
+
+// parseCoords convierte exactamente 'n' campos en enteros
+func parseCoords(fields []string, n int) ([]int, bool) {
+	if len(fields) != n {
+		return nil, false
+	}
+	coords := make([]int, n)
+	for i, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, false
+		}
+		coords[i] = v
+	}
+	return coords, true
+}
+
+// ShowGameMenu muestra el menú de inicio del juego: ¿quieres jugar primero?
+// Como antes, "sí" es jugar primero y el resto (también la entrada cerrada)
+// deja abrir al bot. Las negras siempre abren, así que lo que retorna son las
+// fichas del humano, listas para Game.SetHumanPiece
 // Retorna:
-//   - 'W' si el jugador elige empezar (s/S)
-//   - 'B' para cualquier otra entrada (bot primero)
+//   - 'B' si el jugador elige jugar primero (s/S/y/Y): negras, hace la apertura
+//   - 'W' para cualquier otra entrada: blancas, el bot abre con negras
+//
 // Interacción:
 //   - Muestra prompt y lee entrada simple
 //   - No distingue mayúsculas/minúsculas
-func ShowGameMenu() rune {
-	var choice string
-	fmt.Print("¿Quieres jugar primero? (s/n): ")
-	fmt.Scan(&choice)
-	if choice == "s" || choice == "S" {
-		return 'W' // Jugador es blancas
+func (c *Console) ShowGameMenu() rune {
+	fmt.Fprint(c.out, T("menu.first"))
+	line, _ := c.in.ReadString('\n')
+	if isYes(strings.TrimSpace(line)) {
+		return 'B' // Jugador es negras
+	}
+	return 'W' // Bot es negras
+}
+
+// AskSwap pregunta a 'player', que va a responder a la apertura,
+// si quiere intercambiar colores y quedarse con la piedra ya colocada
+// Retorna: true si la respuesta es s/S
+func (c *Console) AskSwap(player rune) bool {
+	fmt.Fprint(c.out, Tf("swap.ask", PieceName(player)))
+	line, _ := c.in.ReadString('\n')
+	return isYes(strings.TrimSpace(line))
+}
+
+// ShowTurn muestra el número del turno que está por jugarse y las piedras en el tablero
+func ShowTurn(turn, stones int) {
+	fmt.Println(Tf("turn", turn, stones))
+}
+
+// ShowGameLength muestra cuántos turnos duró la partida y las piedras colocadas
+func ShowGameLength(turns, stones int) {
+	fmt.Println(Tf("game.length", turns, stones))
+}
+
+// ShowMove muestra el movimiento que acaba de jugar 'player'
+// Parámetros:
+//   - player: Fichas que movieron
+//   - move: Movimiento aplicado (una o dos piedras)
+func ShowMove(player rune, move board.Move) {
+	fmt.Println(Tf("move.played", PieceName(player), formatMove(move)))
+}
+
+// formatMove da formato "(fila,col) (fila,col)" a un movimiento de una o dos piedras
+func formatMove(move board.Move) string {
+	s := formatPos(move[0])
+	if move[1] != board.NoPosition {
+		s += " " + formatPos(move[1])
 	}
-	return 'B' // Bot es negras
+	return s
+}
+
+// ShowSearchInfo muestra las estadísticas de la búsqueda del bot
+// Parámetro:
+//   - info: Resultado de mcts.SearchWithInfo
+func ShowSearchInfo(info mcts.SearchInfo) {
+	fmt.Println(Tf("search.stats", info.Simulations, info.Visits, info.WinRate*100))
+	fmt.Print(T("search.pv"))
+	for _, move := range info.PV {
+		fmt.Print(" " + formatPos(move[0]))
+		if move[1] != board.NoPosition {
+			fmt.Print("+" + formatPos(move[1]))
+		}
+	}
+	fmt.Println()
+}
+
+// ShowAnalysis muestra los candidatos de mcts.Analyze, uno por línea
+// Parámetros:
+//   - player: Fichas analizadas ('B' o 'W')
+//   - candidates: Candidatos ordenados por visitas
+func ShowAnalysis(player rune, candidates []mcts.Candidate) {
+	fmt.Println(Tf("analyze.header", PieceName(player)))
+	for i, c := range candidates {
+		fmt.Println(Tf("analyze.line", i+1, formatMove(c.Move), c.Visits, c.WinRate*100, c.Eval))
+	}
+	if len(candidates) == 0 {
+		fmt.Println(T("no.moves"))
+	}
+}
+
+// PieceName devuelve el nombre de las fichas de un jugador
+// Parámetro:
+//   - player: 'B' (Negras) o 'W' (Blancas)
+func PieceName(player rune) string {
+	if player == 'W' {
+		return T("white")
+	}
+	return T("black")
 }
 
 // ShowResult muestra el resultado final del juego
@@ -89,10 +333,39 @@ func ShowGameMenu() rune {
 func ShowResult(winner rune) {
 	switch winner {
 	case 'B':
-		fmt.Println("¡Las fichas Negras ganan!")
+		fmt.Println(T("result.black"))
 	case 'W':
-		fmt.Println("¡Las fichas Blancas ganan!")
+		fmt.Println(T("result.white"))
 	default:
-		fmt.Println("¡Es un empate!")
+		fmt.Println(T("result.draw"))
 	}
-}
\ No newline at end of file
+}
+
+// ShowBlockWarning avisa de las casillas que el jugador debe bloquear para no
+// perder (board.FindCriticalBlocks del rival); es solo informativo
+// Parámetro:
+//   - blocks: Casillas críticas (no muestra nada si está vacío)
+func ShowBlockWarning(blocks []board.Position) {
+	if len(blocks) == 0 {
+		return
+	}
+	fmt.Print(T("block.warning"))
+	for _, p := range blocks {
+		fmt.Print(" " + formatPos(p))
+	}
+	fmt.Println()
+}
+
+// ShowWinningLine muestra las posiciones de la línea ganadora
+// Parámetro:
+//   - line: Posiciones de la línea (nil si no hubo ganador)
+func ShowWinningLine(line []board.Position) {
+	if len(line) == 0 {
+		return
+	}
+	fmt.Print(T("win.line"))
+	for _, p := range line {
+		fmt.Print(" " + formatPos(p))
+	}
+	fmt.Println()
+}
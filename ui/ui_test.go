@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"bytes"
+	"connect6/board"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestGetPlayerMoveExplainsErrors: cada jugada inválida recibe su motivo
+// específico y se vuelve a pedir hasta que llega una válida
+func TestGetPlayerMoveExplainsErrors(t *testing.T) {
+	defer SetLang(Lang)
+	SetLang("es")
+	var b board.Board
+	b[9][9] = 'B'
+	b[9][10] = 'W'
+	b[10][10] = 'W'
+
+	input := strings.Join([]string{
+		"19 0 0 1", // fuera de rango
+		"9 9 0 1",  // ocupada
+		"3 3 3 3",  // misma posición
+		"1 2 3",    // cantidad de números
+		"0 0 0 1",  // válida
+	}, "\n") + "\n"
+	var out bytes.Buffer
+	c := NewConsole(strings.NewReader(input), &out)
+	move, ok := c.GetPlayerMove(&b, 'B')
+	if !ok || move != (board.Move{{Row: 0, Col: 0}, {Row: 0, Col: 1}}) {
+		t.Fatalf("move = %v, ok = %v", move, ok)
+	}
+
+	for _, want := range []string{
+		Tf("why.range", "(19,0)", 0, board.BoardSize-1),
+		Tf("why.occupied", "(9,9)"),
+		T("why.same"),
+		Tf("err.numbers", 4),
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("falta el mensaje %q en:\n%s", want, out.String())
+		}
+	}
+	if prompts := strings.Count(out.String(), Tf("prompt.two", PieceName('B'))); prompts != 5 {
+		t.Errorf("se pidió la jugada %d veces, want 5", prompts)
+	}
+}
+
+// captureStdout retorna lo que 'f' escribe en la salida estándar
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+	f()
+	w.Close()
+	return <-done
+}
+
+// TestCoordinateBaseOne: con base 1, "1 1" es la casilla (0,0), "0 0" está
+// fuera del tablero y los encabezados van de 1 a 19
+func TestCoordinateBaseOne(t *testing.T) {
+	defer func(base int) { CoordinateBase = base }(CoordinateBase)
+	CoordinateBase = 1
+
+	var b board.Board
+	var out bytes.Buffer
+	c := NewConsole(strings.NewReader("0 0\n1 1\n"), &out)
+	move, ok := c.GetPlayerMove(&b, 'B')
+	if !ok || move != (board.Move{{Row: 0, Col: 0}, board.NoPosition}) {
+		t.Fatalf("move = %v, ok = %v", move, ok)
+	}
+	if want := Tf("why.range", "(0,0)", 1, board.BoardSize); !strings.Contains(out.String(), want) {
+		t.Errorf("falta %q en:\n%s", want, out.String())
+	}
+
+	lines := strings.Split(captureStdout(t, func() { PrintBoard(b) }), "\n")
+	header := strings.Fields(lines[0])
+	if header[0] != "1" || header[len(header)-1] != "19" {
+		t.Errorf("encabezado de columnas: %q", lines[0])
+	}
+	if first, last := strings.Fields(lines[1])[0], strings.Fields(lines[board.BoardSize])[0]; first != "1" || last != "19" {
+		t.Errorf("filas de %s a %s, want 1 a 19", first, last)
+	}
+}
+
+// TestBlockWarningForOpenFour: con un cuatro abierto del rival el aviso nombra
+// sus casillas críticas; sin amenazas no se imprime nada
+func TestBlockWarningForOpenFour(t *testing.T) {
+	var b board.Board
+	for c := 5; c <= 8; c++ {
+		b[9][c] = 'W'
+	}
+	b[3][3] = 'B'
+
+	blocks := board.FindCriticalBlocks(b, 'W')
+	if len(blocks) == 0 {
+		t.Fatal("FindCriticalBlocks no ve el cuatro abierto")
+	}
+	out := captureStdout(t, func() { ShowBlockWarning(blocks) })
+	if !strings.HasPrefix(out, T("block.warning")) {
+		t.Errorf("aviso = %q, want que empiece con %q", out, T("block.warning"))
+	}
+	for _, p := range blocks {
+		if !strings.Contains(out, formatPos(p)) {
+			t.Errorf("aviso = %q, falta %s", out, formatPos(p))
+		}
+	}
+
+	if out := captureStdout(t, func() { ShowBlockWarning(board.FindCriticalBlocks(b, 'B')) }); out != "" {
+		t.Errorf("sin amenazas se imprimió %q", out)
+	}
+}
+
+// TestPrintBoardHighlightedMarks: sin color, la última jugada sale como [W] y
+// la línea ganadora como *W*; una piedra que está en ambas se marca como línea
+func TestPrintBoardHighlightedMarks(t *testing.T) {
+	defer func(on bool) { colorEnabled = on }(colorEnabled)
+	colorEnabled = false
+
+	var b board.Board
+	var winLine []board.Position
+	for c := 2; c < 8; c++ {
+		b[4][c] = 'W'
+		winLine = append(winLine, board.Position{Row: 4, Col: c})
+	}
+	b[9][9], b[9][10], b[10][9] = 'B', 'B', 'B'
+	b[12][3] = 'W'
+	last := board.Move{{Row: 4, Col: 7}, {Row: 12, Col: 3}}
+
+	want := make(map[board.Position]string)
+	for _, p := range winLine {
+		want[p] = "*W*"
+	}
+	want[board.Position{Row: 12, Col: 3}] = "[W]"
+
+	lines := strings.Split(captureStdout(t, func() { PrintBoardHighlighted(b, last, winLine) }), "\n")
+	for r := 0; r < board.BoardSize; r++ {
+		row := lines[r+1]
+		for c := 0; c < board.BoardSize; c++ {
+			cell := row[3+3*c : 6+3*c]
+			p := board.Position{Row: r, Col: c}
+			expected, marked := want[p]
+			if !marked {
+				char := b[r][c]
+				if char == 0 {
+					char = '.'
+				}
+				expected = " " + string(char) + " "
+			}
+			if cell != expected {
+				t.Errorf("celda %v: %q, want %q", p, cell, expected)
+			}
+		}
+	}
+}